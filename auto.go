@@ -7,7 +7,7 @@ import (
 	"time"
 
 	"github.com/getsentry/sentry-go"
-	otlp "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
 // Config is used to configure xo.
@@ -15,12 +15,37 @@ type Config struct {
 	// The Sentry DSN.
 	SentryDSN string
 
-	// The OTLP endpoint URL.
+	// The OTLP endpoint URL. If present, it overrides the endpoint URL of the
+	// OTLP config.
 	OTLPEndpointURL string
 
+	// The OTLP config, see LoadOTLPConfig.
+	OTLPConfig OTLPConfig
+
 	// The trace service name.
 	TraceServiceName string
 
+	// The service version, also used as the Sentry release.
+	ServiceVersion string
+
+	// The deployment environment, also used as the Sentry environment.
+	Environment string
+
+	// The service instance ID, also used as the Sentry server name. If absent,
+	// the detected host name is used.
+	InstanceID string
+
+	// Additional trace resource attributes.
+	TraceAttributes M
+
+	// The sampler used to sample traces, see SamplingConfig.
+	//
+	// Default: sdkTrace.AlwaysSample().
+	TraceSampler sdkTrace.Sampler
+
+	// The optional tail sampling applied to finished traces.
+	TraceTailSampling *TailSamplingConfig
+
 	// ReportOutput for writing errors.
 	//
 	// Default: os.Stderr.
@@ -57,6 +82,9 @@ func Auto(config Config) func() {
 	// init sentry
 	err := sentry.Init(sentry.ClientOptions{
 		Dsn:          config.SentryDSN,
+		Release:      config.ServiceVersion,
+		Environment:  config.Environment,
+		ServerName:   config.InstanceID,
 		Integrations: FilterSentryIntegrations("ContextifyFrames"),
 		BeforeSend: func(event *sentry.Event, hint *sentry.EventHint) *sentry.Event {
 			// check event silent tag
@@ -73,13 +101,30 @@ func Auto(config Config) func() {
 		Panic(err)
 	}
 
-	// install OTLP if provided
+	// prepare OTLP config
+	otlpConfig := config.OTLPConfig
 	if config.OTLPEndpointURL != "" {
-		exporter, err := otlp.New(context.Background(), otlp.WithEndpointURL(config.OTLPEndpointURL))
+		otlpConfig.EndpointURL = config.OTLPEndpointURL
+	}
+
+	// install OTLP if provided
+	var revertTracing func()
+	if otlpConfig.EndpointURL != "" {
+		exporter, err := NewOTLPExporter(context.Background(), otlpConfig)
 		if err != nil {
 			Capture(err)
 		} else {
-			HookTracing(exporter, config.TraceServiceName, true)
+			revertTracing = HookTracingWithConfig(TracingConfig{
+				Exporter:       exporter,
+				ServiceName:    config.TraceServiceName,
+				ServiceVersion: config.ServiceVersion,
+				Environment:    config.Environment,
+				InstanceID:     config.InstanceID,
+				Attributes:     config.TraceAttributes,
+				Async:          true,
+				Sampler:        config.TraceSampler,
+				TailSampling:   config.TraceTailSampling,
+			})
 		}
 	}
 
@@ -87,6 +132,11 @@ func Auto(config Config) func() {
 		// recover panics
 		Recover(Capture)
 
+		// flush and shut down tracing
+		if revertTracing != nil {
+			revertTracing()
+		}
+
 		// flush
 		sentry.Flush(time.Second)
 	}
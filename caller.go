@@ -15,6 +15,17 @@ type Caller struct {
 	File  string
 	Line  int
 	Stack []uintptr
+
+	// The symbolized frames of a caller without a stack e.g. a decoded remote
+	// caller.
+	Frames []Frame
+}
+
+// Frame describes a symbolized stack frame.
+type Frame struct {
+	Func string `json:"func"`
+	File string `json:"file"`
+	Line int    `json:"line"`
 }
 
 // GetCaller returns information on the caller.
@@ -41,30 +52,32 @@ func GetCaller(skip, limit int) Caller {
 
 // Analyze will fill Short, Full, File and Line from the first stack frame.
 func (c *Caller) Analyze() {
-	// check stack
-	if len(c.Stack) == 0 {
+	// get first frame
+	var first Frame
+	if len(c.Stack) > 0 {
+		frame, _ := runtime.CallersFrames(c.Stack).Next()
+		first = Frame{
+			Func: frame.Function,
+			File: frame.File,
+			Line: frame.Line,
+		}
+	} else if len(c.Frames) > 0 {
+		first = c.Frames[0]
+	} else {
 		return
 	}
 
-	// get first frame
-	frame, _ := runtime.CallersFrames(c.Stack).Next()
-
-	// get name, file and line
-	name := frame.Function
-	file := frame.File
-	line := frame.Line
-
 	// get short name
-	short := name
+	short := first.Func
 	if idx := strings.LastIndex(short, "/"); idx > 0 {
 		short = short[idx+1:]
 	}
 
 	// set names
 	c.Short = short
-	c.Full = name
-	c.File = file
-	c.Line = line
+	c.Full = first.Func
+	c.File = first.File
+	c.Line = first.Line
 }
 
 // Drop will drop the specified amount of frames from the caller.
@@ -132,26 +145,48 @@ func (c Caller) Format(s fmt.State, verb rune) {
 	}
 }
 
-// Print will print the stack to the provided writer.
-func (c Caller) Print(out io.Writer) {
+// Symbolize will return the symbolized frames of the stack or the stored
+// frames if the stack is absent.
+func (c Caller) Symbolize() []Frame {
+	// check stack
+	if len(c.Stack) == 0 {
+		return c.Frames
+	}
+
 	// get frames
 	frames := runtime.CallersFrames(c.Stack)
 
-	// iterate frames
-	var frame runtime.Frame
-	more := true
-	for more {
-		// get next frame
-		frame, more = frames.Next()
+	// collect frames
+	list := make([]Frame, 0, len(c.Stack))
+	for {
+		frame, more := frames.Next()
+		list = append(list, Frame{
+			Func: frame.Function,
+			File: frame.File,
+			Line: frame.Line,
+		})
+		if !more {
+			break
+		}
+	}
+
+	return list
+}
+
+// Print will print the stack to the provided writer.
+func (c Caller) Print(out io.Writer) {
+	// get frames
+	frames := c.Symbolize()
 
-		// print frame
+	// print frames
+	for i, frame := range frames {
 		check(io.WriteString(out, "> "))
-		check(io.WriteString(out, frame.Function))
+		check(io.WriteString(out, frame.Func))
 		check(io.WriteString(out, "\n> \t"))
 		check(io.WriteString(out, frame.File))
 		check(io.WriteString(out, ":"))
 		check(io.WriteString(out, strconv.Itoa(frame.Line)))
-		if more {
+		if i < len(frames)-1 {
 			check(io.WriteString(out, "\n"))
 		}
 	}
@@ -180,7 +180,7 @@ func (d *Debugger) SpanExporter() trace.SpanExporter {
 		for _, root := range roots {
 			WalkTrace(root, func(node *VNode) bool {
 				// check span name
-				length := 2 + node.Depth*2 + len(node.Span.Name)
+				length := 2 + node.Depth*2 + len(spanName(node.Span))
 				if length > longest {
 					longest = length
 				}
@@ -193,6 +193,14 @@ func (d *Debugger) SpanExporter() trace.SpanExporter {
 					}
 				}
 
+				// check links
+				if len(node.Span.Links) > 0 {
+					length := 2 + node.Depth*2 + 1 + len("link")
+					if length > longest {
+						longest = length
+					}
+				}
+
 				return true
 			})
 		}
@@ -215,7 +223,7 @@ func (d *Debugger) SpanExporter() trace.SpanExporter {
 				}
 
 				// prepare name
-				name := prefix + node.Span.Name
+				name := prefix + spanName(node.Span)
 
 				// prepare bar
 				bar := buildBar(node.Span.Start.Sub(root.Span.Start), node.Span.Duration, root.Span.End.Sub(node.Span.End), d.config.TraceWidth)
@@ -261,6 +269,25 @@ func (d *Debugger) SpanExporter() trace.SpanExporter {
 					check(buf.WriteRune('\n'))
 				}
 
+				// print links
+				for _, link := range node.Span.Links {
+					// prepare name
+					name := fmt.Sprintf("%s~link", prefix)
+
+					// prepare target
+					target := link.Trace + "/" + link.Span
+					if d.config.TraceAttributes && len(link.Attributes) > 0 {
+						target += " " + buildMeta(link.Attributes)
+					}
+
+					// build link
+					str := strings.TrimRightFunc(fmt.Sprintf(format, name, repeatString(" ", d.config.TraceWidth), "", target), unicode.IsSpace)
+
+					// print link
+					check(buf.WriteString(str))
+					check(buf.WriteRune('\n'))
+				}
+
 				return true
 			})
 		}
@@ -338,3 +365,12 @@ func (d *Debugger) Report(event *sentry.Event) {
 	_, err := buf.WriteTo(d.config.ReportOutput)
 	check(0, err)
 }
+
+func spanName(span VSpan) string {
+	// highlight failed spans
+	if span.Status == "error" {
+		return span.Name + " !"
+	}
+
+	return span.Name
+}
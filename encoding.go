@@ -0,0 +1,177 @@
+package xo
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+func init() {
+	// register field types
+	gob.Register(M{})
+	gob.Register([]interface{}{})
+}
+
+// EncodedErr is the serializable representation of an error chain. It can be
+// used to transfer errors across process boundaries.
+type EncodedErr struct {
+	Msg         string        `json:"msg,omitempty"`
+	Safe        bool          `json:"safe,omitempty"`
+	Code        string        `json:"code,omitempty"`
+	Class       Class         `json:"class,omitempty"`
+	Fields      M             `json:"fields,omitempty"`
+	Fingerprint []string      `json:"fingerprint,omitempty"`
+	Level       Level         `json:"level,omitempty"`
+	Retryable   bool          `json:"retryable,omitempty"`
+	Permanent   bool          `json:"permanent,omitempty"`
+	RetryAfter  time.Duration `json:"retry_after,omitempty"`
+	Stack       []Frame       `json:"stack,omitempty"`
+	Err         *EncodedErr   `json:"err,omitempty"`
+	Errs        []*EncodedErr `json:"errs,omitempty"`
+	Leaf        bool          `json:"leaf,omitempty"`
+}
+
+// Encode will encode the provided error chain. Errors that are not xo errors
+// are encoded as leaves using their message.
+func Encode(err error) *EncodedErr {
+	// check nil
+	if err == nil {
+		return nil
+	}
+
+	// handle multi error
+	if multiErr, ok := err.(*MultiErr); ok {
+		errs := make([]*EncodedErr, 0, len(multiErr.Errs))
+		for _, err := range multiErr.Errs {
+			errs = append(errs, Encode(err))
+		}
+		return &EncodedErr{
+			Stack: multiErr.Caller.Symbolize(),
+			Errs:  errs,
+		}
+	}
+
+	// get error
+	anErr := asErr(err)
+	if anErr == nil {
+		return &EncodedErr{
+			Msg:  err.Error(),
+			Leaf: true,
+		}
+	}
+
+	// encode error
+	enc := &EncodedErr{
+		Msg:         anErr.Msg,
+		Fields:      anErr.Fields,
+		Fingerprint: anErr.Fingerprint,
+		Level:       anErr.Level,
+		Retryable:   anErr.Retryable,
+		Permanent:   anErr.Permanent,
+		RetryAfter:  anErr.RetryAfter,
+		Stack:       anErr.Caller.Symbolize(),
+		Err:         Encode(anErr.Err),
+	}
+
+	// set safe info
+	if safeErr, ok := err.(*SafeErr); ok {
+		enc.Safe = true
+		enc.Code = safeErr.Code
+		enc.Class = safeErr.Class
+	}
+
+	return enc
+}
+
+// Decode will reconstruct the encoded error chain. The callers of decoded
+// errors carry the encoded frames which are printed by the "%+v" verb.
+func (e *EncodedErr) Decode() error {
+	// check nil
+	if e == nil {
+		return nil
+	}
+
+	// handle leaf
+	if e.Leaf {
+		return errors.New(e.Msg)
+	}
+
+	// prepare caller
+	caller := Caller{
+		Frames: e.Stack,
+	}
+	caller.Analyze()
+
+	// handle multi error
+	if e.Errs != nil {
+		errs := make([]error, 0, len(e.Errs))
+		for _, err := range e.Errs {
+			errs = append(errs, err.Decode())
+		}
+		return &MultiErr{
+			Errs:   errs,
+			Caller: caller,
+		}
+	}
+
+	// decode error
+	anErr := Err{
+		Err:         e.Err.Decode(),
+		Msg:         e.Msg,
+		Fields:      e.Fields,
+		Fingerprint: e.Fingerprint,
+		Level:       e.Level,
+		Caller:      caller,
+		Retryable:   e.Retryable,
+		Permanent:   e.Permanent,
+		RetryAfter:  e.RetryAfter,
+	}
+
+	// handle safe error
+	if e.Safe {
+		return &SafeErr{
+			Err:   anErr,
+			Code:  e.Code,
+			Class: e.Class,
+		}
+	}
+
+	return &anErr
+}
+
+// rawEncodedErr is used to prevent recursive binary marshalling.
+type rawEncodedErr EncodedErr
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (e *EncodedErr) MarshalBinary() ([]byte, error) {
+	// encode error
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode((*rawEncodedErr)(e))
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (e *EncodedErr) UnmarshalBinary(data []byte) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode((*rawEncodedErr)(e))
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (e *Err) MarshalJSON() ([]byte, error) {
+	return json.Marshal(Encode(e))
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (e *SafeErr) MarshalJSON() ([]byte, error) {
+	return json.Marshal(Encode(e))
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (e *MultiErr) MarshalJSON() ([]byte, error) {
+	return json.Marshal(Encode(e))
+}
@@ -0,0 +1,66 @@
+package xo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncode(t *testing.T) {
+	assert.Nil(t, Encode(nil))
+
+	err := SCW(RW(errors.New("foo"), time.Second), NotFound, "missing")
+	err = WithFields(WF(err, "bar"), M{"foo": "bar"})
+
+	enc := Encode(err)
+	assert.Equal(t, "bar", enc.Msg)
+	assert.Equal(t, M{"foo": "bar"}, enc.Fields)
+	assert.Equal(t, "github.com/256dpi/xo.TestEncode", enc.Stack[0].Func)
+	assert.True(t, enc.Err.Safe)
+	assert.Equal(t, NotFound, enc.Err.Class)
+	assert.Equal(t, "missing", enc.Err.Code)
+	assert.True(t, enc.Err.Err.Retryable)
+	assert.Equal(t, time.Second, enc.Err.Err.RetryAfter)
+	assert.Equal(t, &EncodedErr{Msg: "foo", Leaf: true}, enc.Err.Err.Err)
+}
+
+func TestEncodeJSON(t *testing.T) {
+	err := WF(WithFields(SCF(Conflict, "taken", "foo"), M{"foo": "bar"}), "bar")
+
+	buf, err2 := json.Marshal(err)
+	assert.NoError(t, err2)
+
+	var enc EncodedErr
+	err2 = json.Unmarshal(buf, &enc)
+	assert.NoError(t, err2)
+
+	dec := enc.Decode()
+	assert.Equal(t, err.Error(), dec.Error())
+	assert.Equal(t, fmt.Sprintf("%v", err), fmt.Sprintf("%v", dec))
+	assert.Equal(t, fmt.Sprintf("%+v", err), fmt.Sprintf("%+v", dec))
+	assert.True(t, IsSafe(dec))
+	assert.Equal(t, Conflict, AsSafe(dec).Class)
+	assert.Equal(t, "taken", AsSafe(dec).Code)
+	assert.Equal(t, M{"foo": "bar"}, GetFields(dec))
+}
+
+func TestEncodeBinary(t *testing.T) {
+	err := Join(F("foo"), WithFields(W(errors.New("bar")), M{"baz": int64(42)}))
+
+	buf, err2 := Encode(err).MarshalBinary()
+	assert.NoError(t, err2)
+
+	var enc EncodedErr
+	err2 = enc.UnmarshalBinary(buf)
+	assert.NoError(t, err2)
+
+	dec := enc.Decode()
+	assert.IsType(t, &MultiErr{}, dec)
+	assert.Equal(t, err.Error(), dec.Error())
+	assert.Equal(t, fmt.Sprintf("%+v", err), fmt.Sprintf("%+v", dec))
+	assert.Equal(t, M{"baz": int64(42)}, GetFields(dec.(*MultiErr).Errs[1]))
+}
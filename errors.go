@@ -4,13 +4,23 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
+	"time"
 )
 
 // Err is the error returned by F(), W(), WS() and WF().
 type Err struct {
-	Err    error
-	Msg    string
-	Caller Caller
+	Err         error
+	Msg         string
+	Fields      M
+	Fingerprint []string
+	Level       Level
+	Caller      Caller
+
+	// The retry markers.
+	Retryable  bool
+	Permanent  bool
+	RetryAfter time.Duration
 }
 
 // F will format an error. This function can be used instead of errors.New() and
@@ -68,6 +78,205 @@ func WF(err error, format string, args ...interface{}) error {
 	}
 }
 
+// WithFields will attach the provided fields to the error. The error is wrapped
+// if it has not been created by the caller. Existing fields are merged with the
+// provided fields.
+func WithFields(err error, fields M) error {
+	// check nil
+	if err == nil {
+		return nil
+	}
+
+	// ensure caller
+	anErr := WS(err, 1).(*Err)
+
+	// merge fields
+	if anErr.Fields == nil {
+		anErr.Fields = M{}
+	}
+	for key, value := range fields {
+		anErr.Fields[key] = value
+	}
+
+	return anErr
+}
+
+// GetFields will return the merged fields of all errors in the chain. Fields of
+// outer errors take precedence over fields of wrapped errors.
+func GetFields(err error) M {
+	// collect fields
+	var fields M
+	for err != nil {
+		// get fields
+		var list M
+		if anErr := asErr(err); anErr != nil {
+			list = anErr.Fields
+		}
+
+		// merge fields
+		for key, value := range list {
+			if fields == nil {
+				fields = M{}
+			}
+			if _, ok := fields[key]; !ok {
+				fields[key] = value
+			}
+		}
+
+		// unwrap
+		err = errors.Unwrap(err)
+	}
+
+	return fields
+}
+
+// WithFingerprint will set the fingerprint used to group reports of the error.
+// The error is wrapped if it has not been created by the caller.
+func WithFingerprint(err error, fingerprint ...string) error {
+	// check nil
+	if err == nil {
+		return nil
+	}
+
+	// ensure caller
+	anErr := WS(err, 1).(*Err)
+
+	// set fingerprint
+	anErr.Fingerprint = fingerprint
+
+	return anErr
+}
+
+// GetFingerprint will return the first fingerprint found in the chain.
+func GetFingerprint(err error) []string {
+	// find first fingerprint
+	for ; err != nil; err = errors.Unwrap(err) {
+		if anErr := asErr(err); anErr != nil && len(anErr.Fingerprint) > 0 {
+			return anErr.Fingerprint
+		}
+	}
+
+	return nil
+}
+
+// Level describes the severity of an error.
+type Level string
+
+// The available levels.
+const (
+	LevelDebug   Level = "debug"
+	LevelInfo    Level = "info"
+	LevelWarning Level = "warning"
+	LevelError   Level = "error"
+	LevelFatal   Level = "fatal"
+)
+
+// WithLevel will set the severity level used to report the error. The error is
+// wrapped if it has not been created by the caller.
+func WithLevel(err error, level Level) error {
+	// check nil
+	if err == nil {
+		return nil
+	}
+
+	// ensure caller
+	anErr := WS(err, 1).(*Err)
+
+	// set level
+	anErr.Level = level
+
+	return anErr
+}
+
+// GetLevel will return the first level found in the chain or LevelError if
+// absent.
+func GetLevel(err error) Level {
+	// find first level
+	for ; err != nil; err = errors.Unwrap(err) {
+		if anErr := asErr(err); anErr != nil && anErr.Level != "" {
+			return anErr.Level
+		}
+	}
+
+	return LevelError
+}
+
+// RW wraps an error and marks it as retryable. The optional duration specifies
+// the minimum delay before the operation should be retried.
+func RW(err error, after time.Duration) error {
+	// check nil
+	if err == nil {
+		return nil
+	}
+
+	return &Err{
+		Err:        err,
+		Caller:     GetCaller(1, 0),
+		Retryable:  true,
+		RetryAfter: after,
+	}
+}
+
+// PW wraps an error and marks it as permanent.
+func PW(err error) error {
+	// check nil
+	if err == nil {
+		return nil
+	}
+
+	return &Err{
+		Err:       err,
+		Caller:    GetCaller(1, 0),
+		Permanent: true,
+	}
+}
+
+// IsRetryable can be used to check if an error has been marked as retryable
+// using RW. The outermost marker in the chain takes precedence.
+func IsRetryable(err error) bool {
+	anErr := getMarked(err)
+	return anErr != nil && anErr.Retryable
+}
+
+// IsPermanent can be used to check if an error has been marked as permanent
+// using PW. The outermost marker in the chain takes precedence.
+func IsPermanent(err error) bool {
+	anErr := getMarked(err)
+	return anErr != nil && anErr.Permanent
+}
+
+// GetRetryAfter will return the retry delay of a retryable error.
+func GetRetryAfter(err error) time.Duration {
+	anErr := getMarked(err)
+	if anErr == nil {
+		return 0
+	}
+
+	return anErr.RetryAfter
+}
+
+func getMarked(err error) *Err {
+	// find first marked error
+	for ; err != nil; err = errors.Unwrap(err) {
+		if anErr := asErr(err); anErr != nil && (anErr.Retryable || anErr.Permanent) {
+			return anErr
+		}
+	}
+
+	return nil
+}
+
+func asErr(err error) *Err {
+	switch e := err.(type) {
+	case *Err:
+		return e
+	case *SafeErr:
+		return &e.Err
+	}
+
+	return nil
+}
+
 // Error will return the error string.
 func (e *Err) Error() string {
 	if e.Msg != "" && e.Err != nil {
@@ -129,9 +338,44 @@ func Drop(err error, n int) error {
 	return err
 }
 
+// Class describes the status class of a safe error.
+type Class string
+
+// The available classes.
+const (
+	Internal     Class = "internal"
+	NotFound     Class = "not_found"
+	Invalid      Class = "invalid"
+	Conflict     Class = "conflict"
+	Unauthorized Class = "unauthorized"
+)
+
+// Status will return the HTTP status code for the class. Unknown or empty
+// classes are treated as invalid.
+func (c Class) Status() int {
+	switch c {
+	case Internal:
+		return http.StatusInternalServerError
+	case NotFound:
+		return http.StatusNotFound
+	case Conflict:
+		return http.StatusConflict
+	case Unauthorized:
+		return http.StatusUnauthorized
+	default:
+		return http.StatusBadRequest
+	}
+}
+
 // SafeErr wraps Err to indicate presentation safety.
 type SafeErr struct {
 	Err
+
+	// The optional stable error code.
+	Code string
+
+	// The optional status class.
+	Class Class
 }
 
 // SF is a short-hand function to format a safe error.
@@ -160,6 +404,35 @@ func SW(err error) error {
 	}
 }
 
+// SCF is a short-hand function to format a safe error with a class and code.
+func SCF(class Class, code string, format string, args ...interface{}) error {
+	return &SafeErr{
+		Err: Err{
+			Msg:    fmt.Sprintf(format, args...),
+			Caller: GetCaller(1, 0),
+		},
+		Code:  code,
+		Class: class,
+	}
+}
+
+// SCW wraps an error and marks it as safe with a class and code.
+func SCW(err error, class Class, code string) error {
+	// check nil
+	if err == nil {
+		return nil
+	}
+
+	return &SafeErr{
+		Err: Err{
+			Err:    err,
+			Caller: GetCaller(1, 0),
+		},
+		Code:  code,
+		Class: class,
+	}
+}
+
 // IsSafe can be used to check if an error has been wrapped using SW. It will
 // also detect further wrapped safe errors.
 func IsSafe(err error) bool {
@@ -175,27 +448,55 @@ func AsSafe(err error) *SafeErr {
 
 // BaseErr represents the base of an error chain. It cannot directly be used as
 // an error value. Instead, the caller uses Wrap() to get a new wrapped error or
-// Self() to get the identity error.
+// Self() to get the identity error. The identity error carries a fingerprint
+// derived from the declaring function and the format or wrapped error message
+// to group reports of descendants together across releases.
 type BaseErr struct {
 	err error
 }
 
 // BF formats and returns a new base error.
 func BF(format string, args ...interface{}) BaseErr {
+	caller := GetCaller(1, 1)
 	return BaseErr{
 		err: &Err{
-			Msg:    fmt.Sprintf(format, args...),
-			Caller: GetCaller(1, 1),
+			Msg:         fmt.Sprintf(format, args...),
+			Fingerprint: []string{caller.Full, format},
+			Caller:      caller,
 		},
 	}
 }
 
 // BW wraps and returns a new base error.
 func BW(err error) BaseErr {
+	// prepare fingerprint
+	caller := GetCaller(1, 1)
+	fingerprint := []string{caller.Full}
+	if err != nil {
+		fingerprint = append(fingerprint, err.Error())
+	}
+
 	return BaseErr{
 		err: &Err{
-			Err:    err,
-			Caller: GetCaller(1, 1),
+			Err:         err,
+			Fingerprint: fingerprint,
+			Caller:      caller,
+		},
+	}
+}
+
+// BSCF formats and returns a new safe base error with a class and code.
+func BSCF(class Class, code string, format string, args ...interface{}) BaseErr {
+	caller := GetCaller(1, 1)
+	return BaseErr{
+		err: &SafeErr{
+			Err: Err{
+				Msg:         fmt.Sprintf(format, args...),
+				Fingerprint: []string{caller.Full, format},
+				Caller:      caller,
+			},
+			Code:  code,
+			Class: class,
 		},
 	}
 }
@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -308,6 +309,135 @@ func TestSafeErr(t *testing.T) {
 	assert.Equal(t, err2, AsSafe(err3))
 }
 
+func TestWithFields(t *testing.T) {
+	err := WithFields(nil, M{"foo": "bar"})
+	assert.NoError(t, err)
+
+	err = F("foo")
+	assert.Nil(t, GetFields(err))
+
+	err1 := WithFields(err, M{"foo": "bar"})
+	assert.Equal(t, err, err1)
+	assert.Equal(t, M{"foo": "bar"}, GetFields(err1))
+
+	err2 := WithFields(SW(err1), M{"bar": "baz"})
+	assert.True(t, IsSafe(err2))
+	assert.Equal(t, M{"foo": "bar", "bar": "baz"}, GetFields(err2))
+
+	err3 := func() error {
+		return WithFields(WF(err2, "baz"), M{"foo": "quz"})
+	}()
+	assert.Equal(t, "baz: foo", err3.Error())
+	assert.Equal(t, M{"foo": "quz", "bar": "baz"}, GetFields(err3))
+
+	err4 := WithFields(errors.New("foo"), M{"foo": 42})
+	assert.Equal(t, "xo.TestWithFields: foo", fmt.Sprintf("%v", err4))
+	assert.Equal(t, M{"foo": 42}, GetFields(err4))
+}
+
+func TestSCF(t *testing.T) {
+	err := SCF(NotFound, "missing", "foo %d", 42)
+	assert.True(t, IsSafe(err))
+	assert.Equal(t, "foo 42", err.Error())
+	assert.Equal(t, "xo.TestSCF: foo 42", fmt.Sprintf("%v", err))
+
+	safeErr := AsSafe(WF(err, "bar"))
+	assert.Equal(t, NotFound, safeErr.Class)
+	assert.Equal(t, "missing", safeErr.Code)
+}
+
+func TestSCW(t *testing.T) {
+	err := SCW(nil, Conflict, "taken")
+	assert.NoError(t, err)
+
+	err = SCW(errors.New("foo"), Conflict, "taken")
+	assert.True(t, IsSafe(err))
+	assert.Equal(t, "foo", err.Error())
+
+	safeErr := AsSafe(W(err))
+	assert.Equal(t, Conflict, safeErr.Class)
+	assert.Equal(t, "taken", safeErr.Code)
+}
+
+func TestClassStatus(t *testing.T) {
+	assert.Equal(t, 500, Internal.Status())
+	assert.Equal(t, 404, NotFound.Status())
+	assert.Equal(t, 400, Invalid.Status())
+	assert.Equal(t, 409, Conflict.Status())
+	assert.Equal(t, 401, Unauthorized.Status())
+	assert.Equal(t, 400, Class("").Status())
+}
+
+func TestWithFingerprint(t *testing.T) {
+	err := WithFingerprint(nil, "foo")
+	assert.NoError(t, err)
+
+	err = F("foo")
+	assert.Nil(t, GetFingerprint(err))
+
+	err = WithFingerprint(err, "foo")
+	assert.Equal(t, []string{"foo"}, GetFingerprint(err))
+
+	err = WithFingerprint(WF(err, "bar"), "bar")
+	assert.Equal(t, []string{"bar"}, GetFingerprint(err))
+
+	assert.Equal(t, GetFingerprint(baseFoo.Self()), GetFingerprint(baseFoo.WrapF("%d", 42)))
+	assert.Equal(t, GetFingerprint(baseBar.Self()), GetFingerprint(baseBar.Wrap()))
+	assert.NotEqual(t, GetFingerprint(baseFoo.Self()), GetFingerprint(baseBar.Self()))
+
+	assert.Equal(t, []string{"github.com/256dpi/xo.init", "foo"}, GetFingerprint(baseFoo.Self()))
+	assert.Equal(t, []string{"github.com/256dpi/xo.init", "bar"}, GetFingerprint(baseBar.Self()))
+	assert.Equal(t, []string{"github.com/256dpi/xo.init", "missing"}, GetFingerprint(baseMissing.Self()))
+	base1 := BF("foo %d", 1)
+	base2 := BF("foo %d", 2)
+	assert.Equal(t, []string{"github.com/256dpi/xo.TestWithFingerprint", "foo %d"}, GetFingerprint(base1.Self()))
+	assert.Equal(t, GetFingerprint(base1.Self()), GetFingerprint(base2.Self()))
+
+	base3 := BW(nil)
+	assert.Equal(t, []string{"github.com/256dpi/xo.TestWithFingerprint"}, GetFingerprint(base3.Self()))
+}
+
+func TestWithLevel(t *testing.T) {
+	err := WithLevel(nil, LevelInfo)
+	assert.NoError(t, err)
+
+	err = F("foo")
+	assert.Equal(t, LevelError, GetLevel(err))
+
+	err = WithLevel(err, LevelWarning)
+	assert.Equal(t, LevelWarning, GetLevel(err))
+
+	err = WF(err, "bar")
+	assert.Equal(t, LevelWarning, GetLevel(err))
+
+	err = WithLevel(err, LevelFatal)
+	assert.Equal(t, LevelFatal, GetLevel(err))
+}
+
+func TestRWPW(t *testing.T) {
+	err := RW(nil, 0)
+	assert.NoError(t, err)
+
+	err = PW(nil)
+	assert.NoError(t, err)
+
+	err = F("foo")
+	assert.False(t, IsRetryable(err))
+	assert.False(t, IsPermanent(err))
+	assert.Zero(t, GetRetryAfter(err))
+
+	err = WF(RW(err, time.Second), "bar")
+	assert.Equal(t, "bar: foo", err.Error())
+	assert.True(t, IsRetryable(err))
+	assert.False(t, IsPermanent(err))
+	assert.Equal(t, time.Second, GetRetryAfter(err))
+
+	err = SW(PW(err))
+	assert.False(t, IsRetryable(err))
+	assert.True(t, IsPermanent(err))
+	assert.Zero(t, GetRetryAfter(err))
+}
+
 var baseFoo = BF("foo")
 
 func TestBFWrap(t *testing.T) {
@@ -342,6 +472,14 @@ func TestBFWrap(t *testing.T) {
 
 var baseBar = BW(errors.New("bar"))
 
+func TestBWNil(t *testing.T) {
+	assert.NotPanics(t, func() {
+		base := BW(nil)
+		assert.True(t, base.Is(base.Wrap()))
+		assert.NotEmpty(t, GetFingerprint(base.Self()))
+	})
+}
+
 func TestBWWrapF(t *testing.T) {
 	err := baseBar.WrapF("baz")
 	assert.Error(t, err)
@@ -373,6 +511,19 @@ func TestBWWrapF(t *testing.T) {
 	}, splitStackTrace(str))
 }
 
+var baseMissing = BSCF(NotFound, "missing", "missing")
+
+func TestBSCF(t *testing.T) {
+	err := baseMissing.WrapF("foo")
+	assert.True(t, baseMissing.Is(err))
+	assert.True(t, IsSafe(err))
+	assert.Equal(t, "foo: missing", err.Error())
+
+	safeErr := AsSafe(err)
+	assert.Equal(t, NotFound, safeErr.Class)
+	assert.Equal(t, "missing", safeErr.Code)
+}
+
 func BenchmarkF(b *testing.B) {
 	b.ReportAllocs()
 	b.ResetTimer()
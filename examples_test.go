@@ -110,7 +110,7 @@ func ExampleTrace() {
 	// |   Two                          ├──────────────────────────────┤                                   200ms
 	// |   :log                         •                                                                  100ms
 	// |     Three                                      ├──────────────┤                                   100ms
-	// |   Four                                                         ├──────────────────────────────┤   200ms
+	// |   Four !                                                       ├──────────────────────────────┤   200ms
 	// |   :exception                                                   •                                  300ms
 	// |     Five                                                       ├──────────────┤                   100ms
 }
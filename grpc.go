@@ -0,0 +1,252 @@
+package xo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor returns a gRPC interceptor that creates the root trace
+// span for incoming unary calls. Panics are recovered, non-safe errors captured
+// and safe errors mapped to gRPC status codes. Errors that already carry a gRPC
+// status are returned unchanged.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		// create span from metadata
+		ctx, span := Trace(extractMetadata(ctx), info.FullMethod, trace.WithSpanKind(trace.SpanKindServer))
+		span.Set(RPCSystem("grpc"), RPCMethod(info.FullMethod))
+
+		// ensure end
+		defer span.End()
+
+		// call handler
+		var res interface{}
+		err := Catch(func() (err error) {
+			res, err = handler(ctx, req)
+			return err
+		})
+
+		return res, handleRPCError(ctx, span, err)
+	}
+}
+
+// StreamServerInterceptor returns a gRPC interceptor that creates the root
+// trace span for incoming streaming calls. Errors are handled like in
+// UnaryServerInterceptor.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		// create span from metadata
+		ctx, span := Trace(extractMetadata(ss.Context()), info.FullMethod, trace.WithSpanKind(trace.SpanKindServer))
+		span.Set(RPCSystem("grpc"), RPCMethod(info.FullMethod))
+
+		// ensure end
+		defer span.End()
+
+		// call handler
+		err := Catch(func() error {
+			return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		})
+
+		return handleRPCError(ctx, span, err)
+	}
+}
+
+// UnaryClientInterceptor returns a gRPC interceptor that creates a child span
+// for outgoing unary calls and propagates the trace context.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		// create span
+		ctx, span := Trace(ctx, method, trace.WithSpanKind(trace.SpanKindClient))
+		span.Set(RPCSystem("grpc"), RPCMethod(method))
+
+		// ensure end
+		defer span.End()
+
+		// call invoker
+		err := invoker(injectMetadata(ctx), method, req, reply, cc, opts...)
+
+		// finish span
+		finishRPCSpan(span, err)
+
+		return err
+	}
+}
+
+// StreamClientInterceptor returns a gRPC interceptor that creates a child span
+// for outgoing streaming calls and propagates the trace context. The span ends
+// when the stream is exhausted or fails.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		// create span
+		ctx, span := Trace(ctx, method, trace.WithSpanKind(trace.SpanKindClient))
+		span.Set(RPCSystem("grpc"), RPCMethod(method))
+
+		// call streamer
+		stream, err := streamer(injectMetadata(ctx), desc, cc, method, opts...)
+		if err != nil {
+			finishRPCSpan(span, err)
+			span.End()
+			return nil, err
+		}
+
+		return &clientStream{
+			ClientStream: stream,
+			span:         span,
+			unary:        !desc.ServerStreams,
+		}, nil
+	}
+}
+
+func handleRPCError(ctx context.Context, span Span, err error) error {
+	// check error
+	if err == nil {
+		span.Set(RPCGRPCStatusCode(int(codes.OK)))
+		return nil
+	}
+
+	// handle safe errors
+	if safeErr := AsSafe(err); safeErr != nil {
+		code := rpcCode(safeErr.Class)
+		span.Set(RPCGRPCStatusCode(int(code)))
+		recordRPCError(span, code, err)
+		return status.Error(code, safeErr.Error())
+	}
+
+	// handle status errors
+	var statusErr interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &statusErr) {
+		code := statusErr.GRPCStatus().Code()
+		span.Set(RPCGRPCStatusCode(int(code)))
+		recordRPCError(span, code, err)
+		return statusErr.GRPCStatus().Err()
+	}
+
+	// capture error
+	span.Set(RPCGRPCStatusCode(int(codes.Internal)))
+	CaptureContext(ctx, err)
+
+	return status.Error(codes.Internal, codes.Internal.String())
+}
+
+func recordRPCError(span Span, code codes.Code, err error) {
+	// mark server faults only
+	switch code {
+	case codes.Unknown, codes.DeadlineExceeded, codes.Unimplemented, codes.Internal, codes.Unavailable, codes.DataLoss:
+		span.Record(err)
+	default:
+		span.recordError(err)
+	}
+}
+
+func finishRPCSpan(span Span, err error) {
+	// tag code
+	span.Set(RPCGRPCStatusCode(int(status.Code(err))))
+
+	// record error
+	if err != nil {
+		span.Record(err)
+	}
+}
+
+func rpcCode(class Class) codes.Code {
+	switch class {
+	case Internal:
+		return codes.Internal
+	case NotFound:
+		return codes.NotFound
+	case Conflict:
+		return codes.AlreadyExists
+	case Unauthorized:
+		return codes.Unauthenticated
+	default:
+		return codes.InvalidArgument
+	}
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+type clientStream struct {
+	grpc.ClientStream
+	span  Span
+	unary bool
+	once  sync.Once
+}
+
+func (s *clientStream) RecvMsg(m interface{}) error {
+	// receive message
+	err := s.ClientStream.RecvMsg(m)
+
+	// finish span if done
+	if err == io.EOF {
+		s.finish(nil)
+	} else if err != nil {
+		s.finish(err)
+	} else if s.unary {
+		s.finish(nil)
+	}
+
+	return err
+}
+
+func (s *clientStream) finish(err error) {
+	s.once.Do(func() {
+		finishRPCSpan(s.span, err)
+		s.span.End()
+	})
+}
+
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+func extractMetadata(ctx context.Context) context.Context {
+	// get metadata
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	return Propagator.Extract(ctx, metadataCarrier(md))
+}
+
+func injectMetadata(ctx context.Context) context.Context {
+	// copy metadata
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+
+	// inject trace context
+	Propagator.Inject(ctx, metadataCarrier(md))
+
+	return metadata.NewOutgoingContext(ctx, md)
+}
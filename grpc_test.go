@@ -0,0 +1,175 @@
+package xo
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	Test(func(tester *Tester) {
+		interceptor := UnaryServerInterceptor()
+		info := &grpc.UnaryServerInfo{FullMethod: "/foo.Service/Bar"}
+
+		res, err := interceptor(context.Background(), "req", info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return "res", nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "res", res)
+
+		_, err = interceptor(context.Background(), "req", info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, SCF(NotFound, "missing", "not found")
+		})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Equal(t, "not found", status.Convert(err).Message())
+
+		_, err = interceptor(context.Background(), "req", info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, status.Error(codes.Aborted, "aborted")
+		})
+		assert.Equal(t, codes.Aborted, status.Code(err))
+		assert.Equal(t, "aborted", status.Convert(err).Message())
+
+		_, err = interceptor(context.Background(), "req", info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			panic("secret")
+		})
+		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.Equal(t, "Internal", status.Convert(err).Message())
+
+		spans := tester.ReducedSpans(0)
+		assert.Len(t, spans, 4)
+		assert.Equal(t, "/foo.Service/Bar", spans[0].Name)
+		assert.Equal(t, M{
+			"rpc.system":           "grpc",
+			"rpc.method":           "/foo.Service/Bar",
+			"rpc.grpc.status_code": int64(codes.OK),
+		}, spans[0].Attributes)
+		assert.Equal(t, int64(codes.NotFound), spans[1].Attributes["rpc.grpc.status_code"])
+		assert.Equal(t, "", spans[1].Status)
+		assert.Equal(t, "exception", spans[1].Events[0].Name)
+		assert.Equal(t, int64(codes.Aborted), spans[2].Attributes["rpc.grpc.status_code"])
+		assert.Equal(t, "", spans[2].Status)
+		assert.Equal(t, int64(codes.Internal), spans[3].Attributes["rpc.grpc.status_code"])
+		assert.Equal(t, "error", spans[3].Status)
+
+		reports := tester.ReducedReports(false)
+		assert.Len(t, reports, 1)
+		assert.Equal(t, []VException{
+			{Type: "*xo.Err", Value: "PANIC: secret"},
+		}, reports[0].Exceptions)
+	})
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	Test(func(tester *Tester) {
+		interceptor := StreamServerInterceptor()
+		info := &grpc.StreamServerInfo{FullMethod: "/foo.Service/Stream"}
+		stream := &testServerStream{ctx: context.Background()}
+
+		err := interceptor(nil, stream, info, func(srv interface{}, stream grpc.ServerStream) error {
+			_, span := Trace(stream.Context(), "child")
+			span.End()
+			return SCF(Conflict, "taken", "taken")
+		})
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+		assert.Equal(t, []VSpan{
+			{
+				Name: "child",
+			},
+			{
+				Name: "/foo.Service/Stream",
+				Kind: "server",
+				Attributes: M{
+					"rpc.system":           "grpc",
+					"rpc.method":           "/foo.Service/Stream",
+					"rpc.grpc.status_code": int64(codes.AlreadyExists),
+				},
+				Events: []VEvent{
+					{
+						Name: "exception",
+						Attributes: M{
+							"exception.message": "taken",
+							"exception.type":    "*xo.Err",
+						},
+					},
+				},
+			},
+		}, tester.ReducedSpans(0))
+		assert.Equal(t, tester.Spans[1].ID, tester.Spans[0].Parent)
+	})
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	Test(func(tester *Tester) {
+		interceptor := UnaryClientInterceptor()
+
+		var md metadata.MD
+		err := interceptor(context.Background(), "/foo.Service/Bar", nil, nil, nil, func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			md, _ = metadata.FromOutgoingContext(ctx)
+			return status.Error(codes.NotFound, "missing")
+		})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		assert.Len(t, tester.Spans, 1)
+		assert.Equal(t, "/foo.Service/Bar", tester.Spans[0].Name)
+		assert.Equal(t, "error", tester.Spans[0].Status)
+		assert.Equal(t, int64(codes.NotFound), tester.Spans[0].Attributes["rpc.grpc.status_code"])
+		assert.Equal(t, []string{"00-" + tester.Spans[0].Trace + "-" + tester.Spans[0].ID + "-01"}, md.Get("traceparent"))
+	})
+}
+
+func TestStreamClientInterceptor(t *testing.T) {
+	Test(func(tester *Tester) {
+		interceptor := StreamClientInterceptor()
+
+		stream, err := interceptor(context.Background(), &grpc.StreamDesc{ServerStreams: true}, nil, "/foo.Service/Stream", func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			return &testClientStream{msgs: 2}, nil
+		})
+		assert.NoError(t, err)
+
+		assert.NoError(t, stream.RecvMsg(nil))
+		assert.NoError(t, stream.RecvMsg(nil))
+		assert.Empty(t, tester.Spans)
+
+		assert.Equal(t, io.EOF, stream.RecvMsg(nil))
+		assert.Equal(t, []VSpan{
+			{
+				Name: "/foo.Service/Stream",
+				Kind: "client",
+				Attributes: M{
+					"rpc.system":           "grpc",
+					"rpc.method":           "/foo.Service/Stream",
+					"rpc.grpc.status_code": int64(codes.OK),
+				},
+			},
+		}, tester.ReducedSpans(0))
+	})
+}
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testServerStream) Context() context.Context {
+	return s.ctx
+}
+
+type testClientStream struct {
+	grpc.ClientStream
+	msgs int
+}
+
+func (s *testClientStream) RecvMsg(interface{}) error {
+	if s.msgs == 0 {
+		return io.EOF
+	}
+	s.msgs--
+	return nil
+}
@@ -0,0 +1,71 @@
+package xo
+
+import (
+	"net/http"
+)
+
+// HandlerFunc is an HTTP handler that may return an error. Returned errors and
+// recovered panics are handled using HandleError.
+type HandlerFunc func(http.ResponseWriter, *http.Request) error
+
+// ServeHTTP implements the http.Handler interface.
+func (h HandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// track response
+	rw := trackResponse(w)
+
+	// call handler
+	err := Catch(func() error {
+		return h(rw, r)
+	})
+
+	// handle error
+	if err != nil {
+		HandleError(rw, r, err)
+	}
+}
+
+// ErrorHandler is the middleware used to recover panics from the next handler.
+// Recovered panics are handled using HandleError.
+func ErrorHandler() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// track response
+			rw := trackResponse(w)
+
+			// handle panics
+			defer Recover(func(err error) {
+				HandleError(rw, r, err)
+			})
+
+			// call next handler
+			next.ServeHTTP(rw, r)
+		})
+	}
+}
+
+// HandleError will record the error on the span found in the request context,
+// capture the error if it is not safe and write a sanitized problem response.
+// Safe errors only mark the span as failed if their class is Internal.
+// The problem is not written if the response has already been started by a
+// handler wrapped with HandlerFunc, ErrorHandler or RootHandler.
+func HandleError(w http.ResponseWriter, r *http.Request, err error) {
+	// record or capture error
+	if safeErr := AsSafe(err); safeErr != nil {
+		span := NewSpan(r.Context(), GetSpan(r.Context()))
+		if safeErr.Class == Internal {
+			span.Record(err)
+		} else {
+			span.recordError(err)
+		}
+	} else {
+		CaptureContext(r.Context(), err)
+	}
+
+	// check response
+	if rw := trackedResponse(w); rw != nil && rw.started() {
+		return
+	}
+
+	// write problem
+	WriteProblem(w, err)
+}
@@ -0,0 +1,152 @@
+package xo
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/256dpi/serve"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandlerFunc(t *testing.T) {
+	Test(func(tester *Tester) {
+		handler := serve.Compose(
+			RootHandler(),
+			HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+				switch r.URL.Path {
+				case "/safe":
+					return SCF(NotFound, "missing", "not found")
+				case "/internal":
+					return SCF(Internal, "broken", "broken")
+				case "/error":
+					return F("secret")
+				case "/panic":
+					panic("secret")
+				}
+
+				w.WriteHeader(http.StatusOK)
+				return nil
+			}),
+		)
+
+		res := serve.Record(handler, "GET", "/ok", nil, "")
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "", res.Body.String())
+
+		res = serve.Record(handler, "GET", "/safe", nil, "")
+		assert.Equal(t, http.StatusNotFound, res.Code)
+		assert.JSONEq(t, `{"title":"Not Found","status":404,"detail":"not found","code":"missing"}`, res.Body.String())
+
+		res = serve.Record(handler, "GET", "/internal", nil, "")
+		assert.Equal(t, http.StatusInternalServerError, res.Code)
+
+		res = serve.Record(handler, "GET", "/error", nil, "")
+		assert.Equal(t, http.StatusInternalServerError, res.Code)
+		assert.JSONEq(t, `{"title":"Internal Server Error","status":500}`, res.Body.String())
+
+		res = serve.Record(handler, "GET", "/panic", nil, "")
+		assert.Equal(t, http.StatusInternalServerError, res.Code)
+		assert.JSONEq(t, `{"title":"Internal Server Error","status":500}`, res.Body.String())
+
+		reports := tester.ReducedReports(false)
+		assert.Len(t, reports, 2)
+		assert.Equal(t, []VException{
+			{Type: "*xo.Err", Value: "secret"},
+		}, reports[0].Exceptions)
+		assert.Equal(t, []VException{
+			{Type: "*xo.Err", Value: "PANIC: secret"},
+		}, reports[1].Exceptions)
+		assert.Equal(t, tester.Spans[3].ID, reports[0].Tags["span_id"])
+		assert.Equal(t, tester.Spans[4].ID, reports[1].Tags["span_id"])
+
+		spans := tester.ReducedSpans(0)
+		assert.Len(t, spans, 5)
+		assert.Equal(t, "GET /safe", spans[1].Name)
+		assert.Equal(t, "", spans[1].Status)
+		assert.Equal(t, "exception", spans[1].Events[0].Name)
+		assert.Equal(t, "not found", spans[1].Events[0].Attributes["exception.message"])
+		assert.Equal(t, "GET /internal", spans[2].Name)
+		assert.Equal(t, "error", spans[2].Status)
+	})
+}
+
+func TestErrorHandler(t *testing.T) {
+	Test(func(tester *Tester) {
+		handler := serve.Compose(
+			ErrorHandler(),
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				panic(SF("safe"))
+			}),
+		)
+
+		res := serve.Record(handler, "GET", "/", nil, "")
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.JSONEq(t, `{"title":"Bad Request","status":400,"detail":"safe"}`, res.Body.String())
+		assert.Empty(t, tester.Reports)
+	})
+}
+
+func TestHandleErrorStarted(t *testing.T) {
+	Test(func(tester *Tester) {
+		handler := serve.Compose(
+			ErrorHandler(),
+			HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+				w.WriteHeader(http.StatusAccepted)
+				_, _ = w.Write([]byte("partial"))
+				if r.URL.Path == "/panic" {
+					panic("secret")
+				}
+				return F("secret")
+			}),
+		)
+
+		res := serve.Record(handler, "GET", "/error", nil, "")
+		assert.Equal(t, http.StatusAccepted, res.Code)
+		assert.Equal(t, "partial", res.Body.String())
+
+		res = serve.Record(handler, "GET", "/panic", nil, "")
+		assert.Equal(t, http.StatusAccepted, res.Code)
+		assert.Equal(t, "partial", res.Body.String())
+
+		reports := tester.ReducedReports(false)
+		assert.Len(t, reports, 2)
+		assert.Equal(t, "secret", reports[0].Exceptions[0].Value)
+		assert.Equal(t, "PANIC: secret", reports[1].Exceptions[0].Value)
+	})
+	Test(func(tester *Tester) {
+		handler := serve.Compose(
+			ErrorHandler(),
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte("partial"))
+				panic("secret")
+			}),
+		)
+
+		res := serve.Record(handler, "GET", "/", nil, "")
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "partial", res.Body.String())
+		assert.Len(t, tester.Reports, 1)
+	})
+}
+
+func TestHandleErrorFlushed(t *testing.T) {
+	Test(func(tester *Tester) {
+		handler := serve.Compose(
+			RootHandler(),
+			HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+				w.(http.Flusher).Flush()
+				return F("secret")
+			}),
+		)
+
+		res := serve.Record(handler, "GET", "/", nil, "")
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.True(t, res.Flushed)
+		assert.Empty(t, res.Body.String())
+		assert.Len(t, tester.Reports, 1)
+
+		spans := tester.ReducedSpans(0)
+		assert.Len(t, spans, 1)
+		assert.Equal(t, int64(200), spans[0].Attributes["http.status_code"])
+	})
+}
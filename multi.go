@@ -0,0 +1,104 @@
+package xo
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// MultiErr is the error returned by Join().
+type MultiErr struct {
+	Errs   []error
+	Caller Caller
+}
+
+// Join will join the provided errors into a multi error. Nil errors are ignored
+// and nil is returned if no errors remain.
+func Join(errs ...error) error {
+	// filter errors
+	var list []error
+	for _, err := range errs {
+		if err != nil {
+			list = append(list, err)
+		}
+	}
+
+	// check list
+	if len(list) == 0 {
+		return nil
+	}
+
+	return &MultiErr{
+		Errs:   list,
+		Caller: GetCaller(1, 0),
+	}
+}
+
+// Error will return the error string.
+func (e *MultiErr) Error() string {
+	// collect messages
+	messages := make([]string, 0, len(e.Errs))
+	for _, err := range e.Errs {
+		messages = append(messages, err.Error())
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap will return the joined errors.
+func (e *MultiErr) Unwrap() []error {
+	return e.Errs
+}
+
+// Is returns whether any of the joined errors matches the target.
+func (e *MultiErr) Is(target error) bool {
+	for _, err := range e.Errs {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// As finds the first joined error that matches the target.
+func (e *MultiErr) As(target interface{}) bool {
+	for _, err := range e.Errs {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Format will format the error.
+//
+//	%s   messages
+//	%q   "messages"
+//	%v   caller: messages
+//	%+v  errs
+//	     caller
+func (e *MultiErr) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			for _, err := range e.Errs {
+				check(fmt.Fprintf(s, "%+v\n", err))
+			}
+			e.Caller.Format(s, verb)
+		} else {
+			check(fmt.Fprintf(s, "%s: %s", e.Caller.Short, e.Error()))
+		}
+	case 's':
+		check(io.WriteString(s, e.Error()))
+	case 'q':
+		check(fmt.Fprintf(s, "%q", e.Error()))
+	}
+}
+
+// StackTrace will return the stack trace (for sentry compatibility).
+func (e *MultiErr) StackTrace() []uintptr {
+	return e.Caller.Stack
+}
@@ -0,0 +1,82 @@
+package xo
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJoin(t *testing.T) {
+	err := Join(nil, nil)
+	assert.NoError(t, err)
+
+	err1 := F("foo")
+	err2 := SF("bar")
+	err = Join(err1, nil, err2)
+	assert.Error(t, err)
+
+	str := err.Error()
+	assert.Equal(t, "foo; bar", str)
+
+	str = fmt.Sprintf("%s", err)
+	assert.Equal(t, "foo; bar", str)
+
+	str = fmt.Sprintf("%q", err)
+	assert.Equal(t, `"foo; bar"`, str)
+
+	str = fmt.Sprintf("%v", err)
+	assert.Equal(t, "xo.TestJoin: foo; bar", str)
+
+	str = fmt.Sprintf("%+v", err)
+	assert.Equal(t, []string{
+		"foo",
+		"> github.com/256dpi/xo.TestJoin",
+		">   github.com/256dpi/xo/multi_test.go:LN",
+		"> testing.tRunner",
+		">   testing/testing.go:LN",
+		"> runtime.goexit",
+		">   runtime/asm_" + arch + ".s:LN",
+		"bar",
+		"> github.com/256dpi/xo.TestJoin",
+		">   github.com/256dpi/xo/multi_test.go:LN",
+		"> testing.tRunner",
+		">   testing/testing.go:LN",
+		"> runtime.goexit",
+		">   runtime/asm_" + arch + ".s:LN",
+		"> github.com/256dpi/xo.TestJoin",
+		">   github.com/256dpi/xo/multi_test.go:LN",
+		"> testing.tRunner",
+		">   testing/testing.go:LN",
+		"> runtime.goexit",
+		">   runtime/asm_" + arch + ".s:LN",
+	}, splitStackTrace(str))
+
+	assert.True(t, errors.Is(err, err1))
+	assert.True(t, errors.Is(W(err), err2))
+	assert.False(t, errors.Is(err, errFoo))
+	assert.Equal(t, err2, AsSafe(err))
+
+	var multiErr *MultiErr
+	assert.True(t, errors.As(W(err), &multiErr))
+	assert.Equal(t, []error{err1, err2}, multiErr.Errs)
+}
+
+func TestCaptureJoin(t *testing.T) {
+	Test(func(tester *Tester) {
+		Capture(Join(F("foo"), WF(errors.New("bar"), "baz")))
+
+		assert.Equal(t, []VReport{
+			{
+				Level: "error",
+				Exceptions: []VException{
+					{Type: "*xo.Err", Value: "foo"},
+					{Type: "*errors.errorString", Value: "bar"},
+					{Type: "*xo.Err", Value: "baz: bar"},
+					{Type: "*xo.MultiErr", Value: "foo; baz: bar"},
+				},
+			},
+		}, tester.ReducedReports(false))
+	})
+}
@@ -0,0 +1,223 @@
+package xo
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc/credentials"
+)
+
+// The available OTLP protocols.
+const (
+	OTLPHTTP = "http/protobuf"
+	OTLPGRPC = "grpc"
+)
+
+// OTLPConfig is used to configure an OTLP exporter.
+type OTLPConfig struct {
+	// The endpoint URL e.g. "https://collector:4318/v1/traces" for HTTP or
+	// "https://collector:4317" for gRPC.
+	EndpointURL string
+
+	// The protocol, either OTLPHTTP or OTLPGRPC.
+	//
+	// Default: OTLPHTTP.
+	Protocol string
+
+	// The headers sent with every export e.g. for authentication.
+	Headers SM
+
+	// Whether to use an insecure connection.
+	Insecure bool
+
+	// The TLS config used for secure connections.
+	TLSConfig *tls.Config
+
+	// The path of a PEM file with the certificates used to verify the server.
+	CertificateFile string
+
+	// The compression, either "gzip" or "none".
+	//
+	// Default: "none".
+	Compression string
+
+	// The timeout of a single export.
+	//
+	// Default: 10s.
+	Timeout time.Duration
+}
+
+// Ensure will ensure defaults.
+func (c *OTLPConfig) Ensure() {
+	// set default protocol
+	if c.Protocol == "" {
+		c.Protocol = OTLPHTTP
+	}
+
+	// set default compression
+	if c.Compression == "" {
+		c.Compression = "none"
+	}
+
+	// set default timeout
+	if c.Timeout == 0 {
+		c.Timeout = 10 * time.Second
+	}
+}
+
+// LoadOTLPConfig will load an OTLP config from the standard
+// "OTEL_EXPORTER_OTLP_*" environment variables using Load. Trace specific
+// variables take precedence over the general variables.
+func LoadOTLPConfig() OTLPConfig {
+	// prepare config
+	config := OTLPConfig{
+		Protocol:        loadOTLP("PROTOCOL"),
+		Insecure:        loadOTLP("INSECURE") == "true",
+		CertificateFile: loadOTLP("CERTIFICATE"),
+		Compression:     loadOTLP("COMPRESSION"),
+	}
+
+	// load endpoint
+	config.EndpointURL = Load(Var{Name: "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"})
+	if config.EndpointURL == "" {
+		config.EndpointURL = Load(Var{Name: "OTEL_EXPORTER_OTLP_ENDPOINT"})
+		if config.EndpointURL != "" && config.Protocol != OTLPGRPC {
+			config.EndpointURL = strings.TrimSuffix(config.EndpointURL, "/") + "/v1/traces"
+		}
+	}
+
+	// load headers
+	for _, pair := range strings.Split(loadOTLP("HEADERS"), ",") {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+		if unescaped, err := url.QueryUnescape(value); err == nil {
+			value = unescaped
+		}
+		if config.Headers == nil {
+			config.Headers = SM{}
+		}
+		config.Headers[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+
+	// load timeout
+	timeout, err := strconv.Atoi(loadOTLP("TIMEOUT"))
+	if err == nil && timeout > 0 {
+		config.Timeout = time.Duration(timeout) * time.Millisecond
+	}
+
+	return config
+}
+
+func loadOTLP(name string) string {
+	// load trace specific variable
+	value := Load(Var{Name: "OTEL_EXPORTER_OTLP_TRACES_" + name})
+	if value == "" {
+		value = Load(Var{Name: "OTEL_EXPORTER_OTLP_" + name})
+	}
+
+	return value
+}
+
+// NewOTLPExporter will create and return a new OTLP span exporter using the
+// provided config.
+func NewOTLPExporter(ctx context.Context, config OTLPConfig) (sdkTrace.SpanExporter, error) {
+	// ensure config
+	config.Ensure()
+
+	// check compression
+	if config.Compression != "gzip" && config.Compression != "none" {
+		return nil, F("unsupported OTLP compression %q", config.Compression)
+	}
+
+	// prepare TLS config
+	tlsConfig := config.TLSConfig
+	if config.CertificateFile != "" {
+		// read certificates
+		data, err := os.ReadFile(config.CertificateFile)
+		if err != nil {
+			return nil, W(err)
+		}
+
+		// add certificates
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, F("invalid OTLP certificate file")
+		}
+		if tlsConfig != nil {
+			tlsConfig = tlsConfig.Clone()
+		} else {
+			tlsConfig = &tls.Config{}
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	switch config.Protocol {
+	case OTLPHTTP:
+		// prepare options
+		options := []otlptracehttp.Option{
+			otlptracehttp.WithTimeout(config.Timeout),
+		}
+		if config.EndpointURL != "" {
+			options = append(options, otlptracehttp.WithEndpointURL(config.EndpointURL))
+		}
+		if len(config.Headers) > 0 {
+			options = append(options, otlptracehttp.WithHeaders(config.Headers))
+		}
+		if config.Insecure {
+			options = append(options, otlptracehttp.WithInsecure())
+		} else if tlsConfig != nil {
+			options = append(options, otlptracehttp.WithTLSClientConfig(tlsConfig))
+		}
+		if config.Compression == "gzip" {
+			options = append(options, otlptracehttp.WithCompression(otlptracehttp.GzipCompression))
+		}
+
+		// create exporter
+		exporter, err := otlptracehttp.New(ctx, options...)
+		if err != nil {
+			return nil, W(err)
+		}
+
+		return exporter, nil
+	case OTLPGRPC:
+		// prepare options
+		options := []otlptracegrpc.Option{
+			otlptracegrpc.WithTimeout(config.Timeout),
+		}
+		if config.EndpointURL != "" {
+			options = append(options, otlptracegrpc.WithEndpointURL(config.EndpointURL))
+		}
+		if len(config.Headers) > 0 {
+			options = append(options, otlptracegrpc.WithHeaders(config.Headers))
+		}
+		if config.Insecure {
+			options = append(options, otlptracegrpc.WithInsecure())
+		} else if tlsConfig != nil {
+			options = append(options, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(tlsConfig)))
+		}
+		if config.Compression == "gzip" {
+			options = append(options, otlptracegrpc.WithCompressor("gzip"))
+		}
+
+		// create exporter
+		exporter, err := otlptracegrpc.New(ctx, options...)
+		if err != nil {
+			return nil, W(err)
+		}
+
+		return exporter, nil
+	default:
+		return nil, F("unsupported OTLP protocol %q", config.Protocol)
+	}
+}
@@ -0,0 +1,153 @@
+package xo
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type traceService struct {
+	coltracepb.UnimplementedTraceServiceServer
+	md    metadata.MD
+	spans int
+}
+
+func (s *traceService) Export(ctx context.Context, req *coltracepb.ExportTraceServiceRequest) (*coltracepb.ExportTraceServiceResponse, error) {
+	s.md, _ = metadata.FromIncomingContext(ctx)
+	for _, rs := range req.ResourceSpans {
+		for _, ss := range rs.ScopeSpans {
+			s.spans += len(ss.Spans)
+		}
+	}
+	return &coltracepb.ExportTraceServiceResponse{}, nil
+}
+
+func TestLoadOTLPConfig(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://collector:4318/")
+	t.Setenv("OTEL_EXPORTER_OTLP_HEADERS", "Authorization=Bearer%20foo, X-Bar=baz")
+	t.Setenv("OTEL_EXPORTER_OTLP_COMPRESSION", "gzip")
+	t.Setenv("OTEL_EXPORTER_OTLP_TIMEOUT", "5000")
+	t.Setenv("OTEL_EXPORTER_OTLP_INSECURE", "true")
+
+	assert.Equal(t, OTLPConfig{
+		EndpointURL: "http://collector:4318/v1/traces",
+		Headers: SM{
+			"Authorization": "Bearer foo",
+			"X-Bar":         "baz",
+		},
+		Insecure:    true,
+		Compression: "gzip",
+		Timeout:     5 * time.Second,
+	}, LoadOTLPConfig())
+
+	t.Setenv("OTEL_EXPORTER_OTLP_PROTOCOL", "grpc")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_COMPRESSION", "none")
+
+	config := LoadOTLPConfig()
+	assert.Equal(t, OTLPGRPC, config.Protocol)
+	assert.Equal(t, "http://collector:4318/", config.EndpointURL)
+	assert.Equal(t, "none", config.Compression)
+
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "http://collector:4317")
+
+	config = LoadOTLPConfig()
+	assert.Equal(t, "http://collector:4317", config.EndpointURL)
+}
+
+func TestNewOTLPExporterHTTP(t *testing.T) {
+	var path, auth, encoding string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		auth = r.Header.Get("Authorization")
+		encoding = r.Header.Get("Content-Encoding")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	exporter, err := NewOTLPExporter(context.Background(), OTLPConfig{
+		EndpointURL: server.URL + "/v1/traces",
+		Headers:     SM{"Authorization": "Bearer foo"},
+		Compression: "gzip",
+		Timeout:     time.Second,
+	})
+	assert.NoError(t, err)
+
+	revert := HookTracingWithConfig(TracingConfig{
+		Exporter:    exporter,
+		ServiceName: "xo",
+	})
+	defer revert()
+
+	_, span := Trace(context.Background(), "foo")
+	span.End()
+
+	assert.Equal(t, "/v1/traces", path)
+	assert.Equal(t, "Bearer foo", auth)
+	assert.Equal(t, "gzip", encoding)
+
+	err = exporter.Shutdown(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestNewOTLPExporterGRPC(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	service := &traceService{}
+	server := grpc.NewServer()
+	coltracepb.RegisterTraceServiceServer(server, service)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	exporter, err := NewOTLPExporter(context.Background(), OTLPConfig{
+		EndpointURL: "http://" + listener.Addr().String(),
+		Protocol:    OTLPGRPC,
+		Headers:     SM{"Authorization": "Bearer foo"},
+		Insecure:    true,
+		Compression: "gzip",
+		Timeout:     time.Second,
+	})
+	assert.NoError(t, err)
+
+	revert := HookTracingWithConfig(TracingConfig{
+		Exporter:    exporter,
+		ServiceName: "xo",
+	})
+	defer revert()
+
+	_, span := Trace(context.Background(), "foo")
+	span.End()
+
+	assert.Equal(t, 1, service.spans)
+	assert.Equal(t, []string{"Bearer foo"}, service.md.Get("authorization"))
+
+	err = exporter.Shutdown(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestNewOTLPExporterErrors(t *testing.T) {
+	_, err := NewOTLPExporter(context.Background(), OTLPConfig{
+		Protocol: "foo",
+	})
+	assert.Error(t, err)
+	assert.Equal(t, `unsupported OTLP protocol "foo"`, err.Error())
+
+	_, err = NewOTLPExporter(context.Background(), OTLPConfig{
+		Compression: "foo",
+	})
+	assert.Error(t, err)
+	assert.Equal(t, `unsupported OTLP compression "foo"`, err.Error())
+
+	_, err = NewOTLPExporter(context.Background(), OTLPConfig{
+		CertificateFile: "missing.pem",
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,9 @@
+//go:build go1.23
+
+package xo
+
+import "net/http"
+
+func requestPattern(r *http.Request) string {
+	return r.Pattern
+}
@@ -0,0 +1,59 @@
+//go:build go1.23
+
+//go:debug httpmuxgo121=0
+
+package xo
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/256dpi/serve"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRootHandlerPatterns(t *testing.T) {
+	Test(func(tester *Tester) {
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /items/{id}", func(w http.ResponseWriter, r *http.Request) {})
+		mux.HandleFunc("/users/{id}/", func(w http.ResponseWriter, r *http.Request) {})
+
+		handler := serve.Compose(
+			RootHandlerWithConfig(RootConfig{
+				Patterns: true,
+			}),
+			mux,
+		)
+
+		serve.Record(handler, "GET", "/items/42", nil, "")
+		serve.Record(handler, "DELETE", "/users/42/foo", nil, "")
+		serve.Record(handler, "GET", "/missing/42", nil, "")
+
+		spans := tester.ReducedSpans(0)
+		assert.Equal(t, "GET /items/{id}", spans[0].Name)
+		assert.Equal(t, "DELETE /users/{id}/", spans[1].Name)
+		assert.Equal(t, "GET /missing/42", spans[2].Name)
+	})
+}
+
+func TestRootHandlerPatternsMiddleware(t *testing.T) {
+	Test(func(tester *Tester) {
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /users/{id}", func(w http.ResponseWriter, r *http.Request) {})
+
+		handler := serve.Compose(
+			RootHandlerWithConfig(RootConfig{
+				Patterns: true,
+			}),
+			RequestIDHandler(),
+			PatternHandler(mux),
+		)
+
+		serve.Record(handler, "GET", "/users/123", nil, "")
+		serve.Record(handler, "GET", "/missing/123", nil, "")
+
+		spans := tester.ReducedSpans(0)
+		assert.Equal(t, "GET /users/{id}", spans[0].Name)
+		assert.Equal(t, "GET /missing/123", spans[1].Name)
+	})
+}
@@ -0,0 +1,57 @@
+package xo
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Problem is an RFC 7807 problem details object.
+type Problem struct {
+	Type   string `json:"type,omitempty"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail,omitempty"`
+	Code   string `json:"code,omitempty"`
+}
+
+// GetProblem will return the HTTP status code and problem for the provided
+// error. Safe errors are presented using their class, code and message while
+// all other errors are presented as internal server errors.
+func GetProblem(err error) (int, Problem) {
+	// get safe error
+	safeErr := AsSafe(err)
+	if safeErr == nil {
+		return http.StatusInternalServerError, Problem{
+			Title:  http.StatusText(http.StatusInternalServerError),
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	// get status
+	status := safeErr.Class.Status()
+
+	return status, Problem{
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: safeErr.Error(),
+		Code:   safeErr.Code,
+	}
+}
+
+// WriteProblem will write the problem for the provided error to the response
+// writer.
+func WriteProblem(w http.ResponseWriter, err error) {
+	// get problem
+	status, problem := GetProblem(err)
+
+	// encode problem
+	buf, err := json.Marshal(problem)
+	if err != nil {
+		panic(err)
+	}
+
+	// write response
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	check(w.Write(buf))
+}
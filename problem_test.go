@@ -0,0 +1,49 @@
+package xo
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetProblem(t *testing.T) {
+	status, problem := GetProblem(errors.New("foo"))
+	assert.Equal(t, http.StatusInternalServerError, status)
+	assert.Equal(t, Problem{
+		Title:  "Internal Server Error",
+		Status: http.StatusInternalServerError,
+	}, problem)
+
+	status, problem = GetProblem(WF(SF("foo"), "bar"))
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, Problem{
+		Title:  "Bad Request",
+		Status: http.StatusBadRequest,
+		Detail: "foo",
+	}, problem)
+
+	status, problem = GetProblem(W(SCF(NotFound, "missing", "foo")))
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Equal(t, Problem{
+		Title:  "Not Found",
+		Status: http.StatusNotFound,
+		Detail: "foo",
+		Code:   "missing",
+	}, problem)
+}
+
+func TestWriteProblem(t *testing.T) {
+	rec := httptest.NewRecorder()
+	WriteProblem(rec, SCF(Conflict, "taken", "foo"))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{
+		"title": "Conflict",
+		"status": 409,
+		"detail": "foo",
+		"code": "taken"
+	}`, rec.Body.String())
+}
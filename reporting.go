@@ -1,20 +1,55 @@
 package xo
 
 import (
+	"context"
+	"errors"
+	"reflect"
 	"time"
 
 	"github.com/getsentry/sentry-go"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Capture will capture the error.
 func Capture(err error) {
+	capture(nil, ensureCaller(err, 1), "")
+}
+
+// CaptureLevel will capture the error with the provided level. The level takes
+// precedence over the level of the error.
+func CaptureLevel(err error, level Level) {
+	capture(nil, ensureCaller(err, 1), level)
+}
+
+// CaptureContext will capture the error and link the report to the span found
+// in the context using the trace and span IDs. The error is also recorded on
+// the span which sets the span status to error.
+func CaptureContext(ctx context.Context, err error) {
 	// ensure caller
-	if _, ok := err.(*Err); !ok {
-		err = WS(err, 1)
+	err = ensureCaller(err, 1)
+
+	// record error
+	span := GetSpan(ctx)
+	if span != nil {
+		NewSpan(ctx, span).Record(err)
 	}
 
+	// capture error
+	capture(ctx, err, "")
+}
+
+func capture(ctx context.Context, err error, level Level) {
+	// get hub
+	hub := sentry.CurrentHub()
+
 	// forward exception
-	sentry.CaptureException(err)
+	hub.WithScope(func(scope *sentry.Scope) {
+		enrichScope(scope, ctx, err)
+		if level != "" {
+			scope.SetLevel(sentry.Level(level))
+		}
+		captureError(hub.Client(), scope, err)
+	})
 }
 
 var silentReporter = Reporter(SM{"xo:silent": "true"})
@@ -32,18 +67,110 @@ func Reporter(tags SM) func(error) {
 
 	return func(err error) {
 		// ensure caller
-		if _, ok := err.(*Err); !ok {
-			err = WS(err, 1)
-		}
+		err = ensureCaller(err, 1)
 
 		// get client
 		client := sentry.CurrentHub().Client()
 
 		// forward exception
-		client.CaptureException(err, nil, scope)
+		captureError(client, enrichScope(scope.Clone(), nil, err), err)
+	}
+}
+
+func ensureCaller(err error, skip int) error {
+	// wrap errors without a caller
+	switch err.(type) {
+	case *Err, *MultiErr:
+		return err
+	default:
+		return WS(err, 1+skip)
 	}
 }
 
+func captureError(client *sentry.Client, scope *sentry.Scope, err error) {
+	// check client
+	if client == nil {
+		return
+	}
+
+	// prepare event
+	event := client.EventFromException(err, sentry.Level(GetLevel(err)))
+
+	// add joined exceptions
+	event.Exception = append(joinedExceptions(err), event.Exception...)
+
+	// capture event
+	client.CaptureEvent(event, &sentry.EventHint{OriginalException: err}, scope)
+}
+
+func joinedExceptions(err error) []sentry.Exception {
+	// collect exceptions of joined errors in chain
+	var list []sentry.Exception
+	for ; err != nil; err = errors.Unwrap(err) {
+		multiErr, ok := err.(*MultiErr)
+		if !ok {
+			continue
+		}
+		for i, child := range multiErr.Errs {
+			// add nested exceptions
+			list = append(list, joinedExceptions(child)...)
+
+			// add chain exceptions (innermost first)
+			var chain []sentry.Exception
+			for cause := child; cause != nil; cause = errors.Unwrap(cause) {
+				chain = append([]sentry.Exception{{
+					Type:       reflect.TypeOf(cause).String(),
+					Value:      cause.Error(),
+					Stacktrace: sentry.ExtractStacktrace(cause),
+					Mechanism: &sentry.Mechanism{
+						Type: "joined",
+						Data: M{"index": i},
+					},
+				}}, chain...)
+			}
+			list = append(list, chain...)
+		}
+	}
+
+	return list
+}
+
+func enrichScope(scope *sentry.Scope, ctx context.Context, err error) *sentry.Scope {
+	// link span
+	if ctx != nil {
+		spanContext := trace.SpanContextFromContext(ctx)
+		if spanContext.IsValid() {
+			traceID := spanContext.TraceID().String()
+			spanID := spanContext.SpanID().String()
+			scope.SetTag("trace_id", traceID)
+			scope.SetTag("span_id", spanID)
+			scope.SetContext("trace", M{
+				"trace_id": traceID,
+				"span_id":  spanID,
+			})
+		}
+	}
+
+	// tag request id
+	if id := GetRequestID(ctx); id != "" {
+		scope.SetTag("request_id", id)
+	}
+
+	// add fields
+	fields := GetFields(err)
+	if len(fields) > 0 {
+		scope.SetContext("fields", fields)
+	}
+
+	// set fingerprint
+	fingerprint := GetFingerprint(err)
+	if len(fingerprint) > 0 {
+		scope.SetFingerprint(fingerprint)
+	}
+
+	return scope
+}
+
 // HookReporting will set up error reporting using sentry. The returned
 // function may be called to revert the previously configured client.
 func HookReporting(transport sentry.Transport) func() {
@@ -1,6 +1,7 @@
 package xo
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -178,3 +179,167 @@ func TestReporter(t *testing.T) {
 		}, tester.ReducedReports(true))
 	})
 }
+
+func TestCaptureFields(t *testing.T) {
+	Test(func(tester *Tester) {
+		err := WithFields(F("foo"), M{"foo": "bar"})
+
+		Capture(err)
+		Reporter(SM{"foo": "bar"})(err)
+
+		assert.Equal(t, []VReport{
+			{
+				Level: "error",
+				Context: M{
+					"fields": M{"foo": "bar"},
+				},
+				Exceptions: []VException{
+					{Type: "*xo.Err", Value: "foo"},
+				},
+			},
+			{
+				Level: "error",
+				Context: M{
+					"fields": M{"foo": "bar"},
+				},
+				Tags: M{
+					"foo": "bar",
+				},
+				Exceptions: []VException{
+					{Type: "*xo.Err", Value: "foo"},
+				},
+			},
+		}, tester.ReducedReports(false))
+	})
+}
+
+func TestCaptureFingerprint(t *testing.T) {
+	Test(func(tester *Tester) {
+		Capture(baseFoo.WrapF("bar %d", 1))
+		CaptureSilent(baseFoo.WrapF("bar %d", 2))
+		Reporter(nil)(WithFingerprint(F("baz"), "baz"))
+
+		assert.Equal(t, []VReport{
+			{
+				Level:       "error",
+				Fingerprint: GetFingerprint(baseFoo.Self()),
+				Exceptions: []VException{
+					{Type: "*xo.Err", Value: "foo"},
+					{Type: "*xo.Err", Value: "bar 1: foo"},
+				},
+			},
+			{
+				Level:       "error",
+				Tags:        M{"xo:silent": "true"},
+				Fingerprint: GetFingerprint(baseFoo.Self()),
+				Exceptions: []VException{
+					{Type: "*xo.Err", Value: "foo"},
+					{Type: "*xo.Err", Value: "bar 2: foo"},
+				},
+			},
+			{
+				Level:       "error",
+				Fingerprint: []string{"baz"},
+				Exceptions: []VException{
+					{Type: "*xo.Err", Value: "baz"},
+				},
+			},
+		}, tester.ReducedReports(false))
+	})
+}
+
+func TestCaptureLevel(t *testing.T) {
+	Test(func(tester *Tester) {
+		Capture(WithLevel(F("foo"), LevelWarning))
+		CaptureLevel(F("bar"), LevelInfo)
+		CaptureLevel(errors.New("baz"), LevelFatal)
+		Reporter(nil)(WithLevel(F("qux"), LevelDebug))
+
+		assert.Equal(t, []VReport{
+			{
+				Level: "warning",
+				Exceptions: []VException{
+					{Type: "*xo.Err", Value: "foo"},
+				},
+			},
+			{
+				Level: "info",
+				Exceptions: []VException{
+					{Type: "*xo.Err", Value: "bar"},
+				},
+			},
+			{
+				Level: "fatal",
+				Exceptions: []VException{
+					{Type: "*errors.errorString", Value: "baz"},
+					{Type: "*xo.Err", Value: "baz"},
+				},
+			},
+			{
+				Level: "debug",
+				Exceptions: []VException{
+					{Type: "*xo.Err", Value: "qux"},
+				},
+			},
+		}, tester.ReducedReports(false))
+	})
+}
+
+func TestCaptureContext(t *testing.T) {
+	Test(func(tester *Tester) {
+		ctx, span := Trace(context.Background(), "foo")
+		CaptureContext(ctx, F("foo"))
+		span.End()
+
+		traceID := span.Native().SpanContext().TraceID().String()
+		spanID := span.Native().SpanContext().SpanID().String()
+
+		assert.Equal(t, []VReport{
+			{
+				Level: "error",
+				Context: M{
+					"trace": M{
+						"trace_id": traceID,
+						"span_id":  spanID,
+					},
+				},
+				Tags: M{
+					"trace_id": traceID,
+					"span_id":  spanID,
+				},
+				Exceptions: []VException{
+					{Type: "*xo.Err", Value: "foo"},
+				},
+			},
+		}, tester.ReducedReports(false))
+
+		assert.Equal(t, []VSpan{
+			{
+				Name:   "foo",
+				Status: "error",
+				Events: []VEvent{
+					{
+						Name: "exception",
+						Attributes: M{
+							"exception.message": "foo",
+							"exception.type":    "*xo.Err",
+						},
+					},
+				},
+			},
+		}, tester.ReducedSpans(0))
+	})
+
+	Test(func(tester *Tester) {
+		CaptureContext(context.Background(), F("foo"))
+
+		assert.Equal(t, []VReport{
+			{
+				Level: "error",
+				Exceptions: []VException{
+					{Type: "*xo.Err", Value: "foo"},
+				},
+			},
+		}, tester.ReducedReports(false))
+	})
+}
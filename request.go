@@ -0,0 +1,90 @@
+package xo
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// RequestIDHeader is the header used to read and echo request IDs.
+const RequestIDHeader = "X-Request-ID"
+
+type requestIDContextKey struct{}
+
+var requestIDKey = requestIDContextKey{}
+
+// WithRequestID will return a context that carries the provided request ID.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+// GetRequestID will return the request ID stored in the provided context. It
+// will return an empty string if no request ID has been found.
+func GetRequestID(ctx context.Context) string {
+	// check context
+	if ctx == nil {
+		return ""
+	}
+
+	// get id
+	id, _ := ctx.Value(requestIDKey).(string)
+
+	return id
+}
+
+// RequestIDHandler is the middleware used to read or generate a request ID.
+// The ID is stored in the request context, tagged on the current span and
+// echoed in the response. It should be placed after the root handler. If the
+// root handler uses patterns, the mux must be wrapped with PatternHandler.
+func RequestIDHandler() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// get or generate id
+			id := r.Header.Get(RequestIDHeader)
+			if !isRequestID(id) {
+				id = newRequestID()
+			}
+
+			// store id
+			ctx := WithRequestID(r.Context(), id)
+			r = r.WithContext(ctx)
+
+			// tag span
+			NewSpan(ctx, GetSpan(ctx)).Tag("http.request_id", id)
+
+			// echo id
+			w.Header().Set(RequestIDHeader, id)
+
+			// call next handler
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func newRequestID() string {
+	// read random bytes
+	var buf [16]byte
+	_, err := rand.Read(buf[:])
+	if err != nil {
+		panic(err)
+	}
+
+	return hex.EncodeToString(buf[:])
+}
+
+func isRequestID(id string) bool {
+	// check length
+	if len(id) == 0 || len(id) > 128 {
+		return false
+	}
+
+	// check characters
+	for _, r := range id {
+		if r < '!' || r > '~' {
+			return false
+		}
+	}
+
+	return true
+}
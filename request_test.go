@@ -0,0 +1,69 @@
+package xo
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/256dpi/serve"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestID(t *testing.T) {
+	assert.Equal(t, "", GetRequestID(context.Background()))
+	assert.Equal(t, "foo", GetRequestID(WithRequestID(context.Background(), "foo")))
+}
+
+func TestRequestIDHandler(t *testing.T) {
+	Test(func(tester *Tester) {
+		var id string
+		handler := serve.Compose(
+			RootHandler(),
+			RequestIDHandler(),
+			HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+				id = GetRequestID(r.Context())
+				return F("error")
+			}),
+		)
+
+		res := serve.Record(handler, "GET", "/foo", map[string]string{
+			RequestIDHeader: "abc-123",
+		}, "")
+		assert.Equal(t, http.StatusInternalServerError, res.Code)
+		assert.Equal(t, "abc-123", id)
+		assert.Equal(t, "abc-123", res.Header().Get(RequestIDHeader))
+
+		spans := tester.ReducedSpans(0)
+		assert.Len(t, spans, 1)
+		assert.Equal(t, "abc-123", spans[0].Attributes["http.request_id"])
+
+		reports := tester.ReducedReports(false)
+		assert.Len(t, reports, 1)
+		assert.Equal(t, "abc-123", reports[0].Tags["request_id"])
+	})
+
+	Test(func(tester *Tester) {
+		var id string
+		handler := serve.Compose(
+			RootHandler(),
+			RequestIDHandler(),
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				id = GetRequestID(r.Context())
+				w.WriteHeader(http.StatusOK)
+			}),
+		)
+
+		res := serve.Record(handler, "GET", "/foo", map[string]string{
+			RequestIDHeader: strings.Repeat("x", 200),
+		}, "")
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Len(t, id, 32)
+		assert.Equal(t, id, res.Header().Get(RequestIDHeader))
+
+		res = serve.Record(handler, "GET", "/foo", nil, "")
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Len(t, id, 32)
+		assert.Equal(t, id, res.Header().Get(RequestIDHeader))
+	})
+}
@@ -0,0 +1,93 @@
+package xo
+
+import (
+	"os"
+	"runtime"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/semconv/v1.5.0"
+)
+
+// DetectResource will return the resource attributes detected for the current
+// host, process and container.
+func DetectResource() M {
+	// prepare attributes
+	attrs := M{
+		string(semconv.ProcessPIDKey):            os.Getpid(),
+		string(semconv.ProcessRuntimeNameKey):    "go",
+		string(semconv.ProcessRuntimeVersionKey): runtime.Version(),
+	}
+
+	// add host name
+	hostname, err := os.Hostname()
+	if err == nil && hostname != "" {
+		attrs[string(semconv.HostNameKey)] = hostname
+	}
+
+	// add container id
+	for _, file := range []string{"/proc/self/cgroup", "/proc/self/mountinfo"} {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		if id := findContainerID(string(data)); id != "" {
+			attrs[string(semconv.ContainerIDKey)] = id
+			break
+		}
+	}
+
+	return attrs
+}
+
+func buildResource(config TracingConfig) []attribute.KeyValue {
+	// prepare attributes
+	attrs := M{}
+
+	// add detected attributes
+	if !config.NoDetection {
+		for key, value := range DetectResource() {
+			attrs[key] = value
+		}
+	}
+
+	// add service attributes
+	attrs[string(semconv.ServiceNameKey)] = config.ServiceName
+	if config.ServiceVersion != "" {
+		attrs[string(semconv.ServiceVersionKey)] = config.ServiceVersion
+	}
+	if config.Environment != "" {
+		attrs[string(semconv.DeploymentEnvironmentKey)] = config.Environment
+	}
+	if config.InstanceID != "" {
+		attrs[string(semconv.ServiceInstanceIDKey)] = config.InstanceID
+	}
+
+	// add custom attributes
+	for key, value := range config.Attributes {
+		attrs[key] = value
+	}
+
+	return mapToKV(attrs)
+}
+
+func findContainerID(data string) string {
+	// check lines
+	for _, line := range strings.Split(data, "\n") {
+		for _, segment := range strings.Split(line, "/") {
+			// trim runtime prefixes and suffixes
+			segment, _, _ = strings.Cut(segment, " ")
+			segment = strings.TrimSuffix(segment, ".scope")
+			if i := strings.LastIndexAny(segment, "-:"); i >= 0 {
+				segment = segment[i+1:]
+			}
+
+			// check id
+			if len(segment) == 64 && isHex(segment) {
+				return segment
+			}
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,77 @@
+package xo
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestDetectResource(t *testing.T) {
+	attrs := DetectResource()
+	assert.Equal(t, os.Getpid(), attrs["process.pid"])
+	assert.Equal(t, "go", attrs["process.runtime.name"])
+	assert.Equal(t, runtime.Version(), attrs["process.runtime.version"])
+	assert.NotEmpty(t, attrs["host.name"])
+}
+
+func TestFindContainerID(t *testing.T) {
+	id := "4bf92f3577b34da6a3ce929d0e0e47364bf92f3577b34da6a3ce929d0e0e4736"
+
+	assert.Equal(t, "", findContainerID(""))
+	assert.Equal(t, "", findContainerID("0::/\n"))
+	assert.Equal(t, id, findContainerID("12:pids:/docker/"+id+"\n"))
+	assert.Equal(t, id, findContainerID("0::/system.slice/docker-"+id+".scope\n"))
+	assert.Equal(t, id, findContainerID("0::/kubepods/burstable/pod1/cri-containerd:"+id+"\n"))
+	assert.Equal(t, id, findContainerID("612 590 0:45 /var/lib/docker/containers/"+id+"/hostname /etc/hostname rw\n"))
+}
+
+func TestHookTracingResource(t *testing.T) {
+	var attrs M
+	revert := HookTracingWithConfig(TracingConfig{
+		Exporter: SpanExporter(func(span trace.ReadOnlySpan) error {
+			attrs = kvToMap(span.Resource().Attributes())
+			return nil
+		}),
+		ServiceName:    "foo",
+		ServiceVersion: "1.2.3",
+		Environment:    "production",
+		InstanceID:     "foo-1",
+		Attributes: M{
+			"team":      "bar",
+			"host.name": "baz",
+		},
+	})
+
+	_, span := Trace(context.Background(), "foo")
+	span.End()
+
+	assert.Equal(t, "foo", attrs["service.name"])
+	assert.Equal(t, "1.2.3", attrs["service.version"])
+	assert.Equal(t, "production", attrs["deployment.environment"])
+	assert.Equal(t, "foo-1", attrs["service.instance.id"])
+	assert.Equal(t, "bar", attrs["team"])
+	assert.Equal(t, "baz", attrs["host.name"])
+	assert.Equal(t, int64(os.Getpid()), attrs["process.pid"])
+
+	revert()
+
+	revert = HookTracingWithConfig(TracingConfig{
+		Exporter: SpanExporter(func(span trace.ReadOnlySpan) error {
+			attrs = kvToMap(span.Resource().Attributes())
+			return nil
+		}),
+		ServiceName: "foo",
+		NoDetection: true,
+	})
+
+	_, span = Trace(context.Background(), "foo")
+	span.End()
+
+	revert()
+
+	assert.Equal(t, M{"service.name": "foo"}, attrs)
+}
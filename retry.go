@@ -0,0 +1,169 @@
+package xo
+
+import (
+	"context"
+	"time"
+)
+
+// Backoff is used to configure Retry.
+type Backoff struct {
+	// The maximum number of attempts.
+	//
+	// Default: 3.
+	Attempts int
+
+	// The delay before the first retry.
+	//
+	// Default: 100ms.
+	Delay time.Duration
+
+	// The maximum delay between retries.
+	//
+	// Default: 10s.
+	MaxDelay time.Duration
+
+	// The factor by which the delay grows after each retry.
+	//
+	// Default: 2.
+	Factor float64
+
+	// Whether to also retry errors that have neither been marked as retryable
+	// using RW nor as permanent using PW.
+	RetryUnmarked bool
+}
+
+// Ensure will ensure defaults.
+func (b *Backoff) Ensure() {
+	// set default attempts
+	if b.Attempts == 0 {
+		b.Attempts = 3
+	}
+
+	// set default delay
+	if b.Delay == 0 {
+		b.Delay = 100 * time.Millisecond
+	}
+
+	// set default max delay
+	if b.MaxDelay == 0 {
+		b.MaxDelay = 10 * time.Second
+	}
+
+	// set default factor
+	if b.Factor == 0 {
+		b.Factor = 2
+	}
+}
+
+// Retry will run the provided function until it succeeds, returns an error that
+// is not retryable or the attempts are exhausted. Only errors marked with RW are
+// retried, unless RetryUnmarked is set. Errors marked with PW are never retried.
+// Each attempt is traced as a child span and panics are recovered. The delay of
+// retryable errors takes precedence over the backoff delay. The final error is
+// recorded and returned.
+func Retry(ctx context.Context, backoff Backoff, fn func(ctx *Context) error) error {
+	// ensure context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// ensure backoff
+	backoff.Ensure()
+
+	// get caller
+	caller := GetCaller(1, 0)
+
+	// trace
+	ctx, span := Trace(ctx, caller.Short)
+	defer span.End()
+
+	// prepare delay
+	delay := backoff.Delay
+
+	// run attempts
+	var err error
+	for attempt := 1; ; attempt++ {
+		// run attempt
+		err = retryAttempt(ctx, caller, attempt, fn)
+		if err == nil {
+			return nil
+		}
+
+		// check error and attempts
+		if !shouldRetry(err, backoff) || attempt >= backoff.Attempts {
+			break
+		}
+
+		// get wait
+		wait := delay
+		if after := GetRetryAfter(err); after > 0 {
+			wait = after
+		}
+
+		// await delay
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			err = Join(err, ctx.Err())
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		// grow delay
+		delay = time.Duration(float64(delay) * backoff.Factor)
+		if delay > backoff.MaxDelay {
+			delay = backoff.MaxDelay
+		}
+	}
+
+	// wrap error
+	err = &Err{
+		Err:    err,
+		Caller: caller,
+	}
+
+	// record error
+	span.Record(err)
+
+	return err
+}
+
+func shouldRetry(err error, backoff Backoff) bool {
+	// check markers
+	if IsPermanent(err) {
+		return false
+	} else if IsRetryable(err) {
+		return true
+	}
+
+	return backoff.RetryUnmarked
+}
+
+func retryAttempt(ctx context.Context, caller Caller, attempt int, fn func(ctx *Context) error) error {
+	// trace attempt
+	ctx, span := Trace(ctx, "attempt")
+	span.Tag("attempt", attempt)
+	defer span.End()
+
+	// wrap
+	xtc := &Context{
+		Caller:  caller,
+		Context: ctx,
+		Span:    span,
+	}
+
+	// yield
+	err := Catch(func() error {
+		return fn(xtc)
+	})
+
+	// record error
+	if err != nil {
+		span.Record(err)
+	}
+
+	return err
+}
@@ -0,0 +1,133 @@
+package xo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetry(t *testing.T) {
+	Test(func(tester *Tester) {
+		var attempts int
+		err := Retry(nil, Backoff{Delay: time.Millisecond}, func(ctx *Context) error {
+			attempts++
+			if attempts < 2 {
+				return RW(F("fail"), 0)
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, attempts)
+
+		attempts = 0
+		err = Retry(nil, Backoff{Delay: time.Millisecond}, func(ctx *Context) error {
+			attempts++
+			return PW(F("fail"))
+		})
+		assert.Error(t, err)
+		assert.True(t, IsPermanent(err))
+		assert.Equal(t, 1, attempts)
+
+		attempts = 0
+		err = Retry(nil, Backoff{Attempts: 2, Delay: time.Hour}, func(ctx *Context) error {
+			attempts++
+			return RW(F("fail"), time.Millisecond)
+		})
+		assert.Error(t, err)
+		assert.Equal(t, "fail", err.Error())
+		assert.Equal(t, 2, attempts)
+
+		assert.Equal(t, []VSpan{
+			{
+				Name:       "attempt",
+				Status:     "error",
+				Attributes: M{"attempt": int64(1)},
+				Events: []VEvent{
+					{
+						Name: "exception",
+						Attributes: M{
+							"exception.message": "fail",
+							"exception.type":    "*xo.Err",
+						},
+					},
+				},
+			},
+			{
+				Name:       "attempt",
+				Attributes: M{"attempt": int64(2)},
+			},
+			{
+				Name: "xo.TestRetry.func1",
+			},
+			{
+				Name:       "attempt",
+				Status:     "error",
+				Attributes: M{"attempt": int64(1)},
+				Events: []VEvent{
+					{
+						Name: "exception",
+						Attributes: M{
+							"exception.message": "fail",
+							"exception.type":    "*xo.Err",
+						},
+					},
+				},
+			},
+			{
+				Name:   "xo.TestRetry.func1",
+				Status: "error",
+				Events: []VEvent{
+					{
+						Name: "exception",
+						Attributes: M{
+							"exception.message": "fail",
+							"exception.type":    "*xo.Err",
+						},
+					},
+				},
+			},
+		}, tester.ReducedSpans(0)[:5])
+	})
+}
+
+func TestRetryUnmarked(t *testing.T) {
+	var attempts int
+	err := Retry(nil, Backoff{Delay: time.Millisecond}, func(ctx *Context) error {
+		attempts++
+		return F("fail")
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts)
+
+	attempts = 0
+	err = Retry(nil, Backoff{Delay: time.Millisecond, RetryUnmarked: true}, func(ctx *Context) error {
+		attempts++
+		return F("fail")
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 3, attempts)
+
+	attempts = 0
+	err = Retry(nil, Backoff{Delay: time.Millisecond, RetryUnmarked: true}, func(ctx *Context) error {
+		attempts++
+		return PW(F("fail"))
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestRetryContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var attempts int
+	err := Retry(ctx, Backoff{}, func(ctx *Context) error {
+		attempts++
+		return RW(F("fail"), 0)
+	})
+	assert.Error(t, err)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, attempts)
+}
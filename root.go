@@ -1,43 +1,434 @@
 package xo
 
 import (
+	"bufio"
+	"context"
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
 	"strings"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// RootConfig is used to configure the root handler.
+type RootConfig struct {
+	// The cleaners applied to the URL segments to construct the span name.
+	Cleaners []func([]string) []string
+
+	// The function used to construct the span name. If absent, the name is
+	// constructed from the method and the cleaned URL path.
+	Namer func(*http.Request) string
+
+	// Whether to rename the span using the pattern of the matched http.ServeMux
+	// route if available. This requires Go 1.23+ and the enhanced routing
+	// patterns (not disabled via GODEBUG=httpmuxgo121=1). If middleware between
+	// the root handler and the mux replaces the request e.g. using
+	// r.WithContext, the mux must be wrapped with PatternHandler.
+	Patterns bool
+
+	// The propagator used to extract the trace context from incoming headers.
+	//
+	// Default: Propagator.
+	Propagator propagation.TextMapPropagator
+
+	// Whether to additionally extract baggage from incoming headers.
+	Baggage bool
+
+	// The request headers to tag on the span.
+	RequestHeaders []string
+
+	// The response headers to tag on the span.
+	ResponseHeaders []string
+
+	// The query parameters to tag on the span.
+	QueryParams []string
+
+	// The keys of headers and query parameters whose values are redacted in
+	// tags and the URL. A key matches if it contains an entry, ignoring case.
+	//
+	// Default: SensitiveKeys.
+	SensitiveKeys []string
+}
+
+type patternContextKey struct{}
+
+var patternKey = patternContextKey{}
+
+// SensitiveKeys is the default list of sensitive header and query keys.
+var SensitiveKeys = []string{"authorization", "cookie", "token", "password", "secret"}
+
+// Ensure will ensure defaults.
+func (c *RootConfig) Ensure() {
+	// set default propagator
+	if c.Propagator == nil {
+		c.Propagator = Propagator
+	}
+
+	// set default sensitive keys
+	if c.SensitiveKeys == nil {
+		c.SensitiveKeys = SensitiveKeys
+	}
+}
+
 // RootHandler is the middleware used to create the root trace span for
 // incoming HTTP requests.
 func RootHandler(cleaners ...func([]string) []string) func(http.Handler) http.Handler {
+	return RootHandlerWithConfig(RootConfig{
+		Cleaners: cleaners,
+	})
+}
+
+// RootHandlerWithConfig is the middleware used to create the root trace span
+// for incoming HTTP requests using the provided config. Incoming trace context
+// is extracted so that the span joins upstream traces.
+func RootHandlerWithConfig(config RootConfig) func(http.Handler) http.Handler {
+	// ensure config
+	config.Ensure()
+
+	// prepare propagator
+	propagator := config.Propagator
+	if config.Baggage {
+		propagator = propagation.NewCompositeTextMapPropagator(propagator, propagation.Baggage{})
+	}
+
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// split url
-			segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
-
-			// run cleaners
-			for _, cleaner := range cleaners {
-				segments = cleaner(segments)
+			// construct name
+			var name string
+			if config.Namer != nil {
+				name = config.Namer(r)
+			} else {
+				name = fmt.Sprintf("%s /%s", r.Method, cleanPath(r.URL.Path, config.Cleaners))
 			}
 
-			// construct name
-			path := strings.Join(segments, "/")
-			name := fmt.Sprintf("%s /%s", r.Method, path)
+			// extract context
+			ctx := propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
 
 			// create span from request
-			ctx, span := Trace(r.Context(), name)
+			ctx, span := Trace(ctx, name, trace.WithSpanKind(trace.SpanKindServer))
 			span.Tag("http.proto", r.Proto)
-			span.Tag("http.host", r.Host)
-			span.Tag("http.url", r.URL.String())
+			span.Set(HTTPHost(r.Host), HTTPURL(redactURL(r.URL, config.SensitiveKeys)))
+
+			// tag request headers
+			for _, key := range config.RequestHeaders {
+				if values := r.Header.Values(key); len(values) > 0 {
+					span.Tag("http.request.header."+strings.ToLower(key), redactValue(key, strings.Join(values, ", "), config.SensitiveKeys))
+				}
+			}
+
+			// tag query parameters
+			if len(config.QueryParams) > 0 {
+				query := r.URL.Query()
+				for _, key := range config.QueryParams {
+					if values, ok := query[key]; ok {
+						span.Tag("http.query."+key, redactValue(key, strings.Join(values, ", "), config.SensitiveKeys))
+					}
+				}
+			}
 
 			// ensure end
 			defer span.End()
 
+			// prepare pattern holder
+			var pattern *string
+			if config.Patterns {
+				pattern = new(string)
+				ctx = context.WithValue(ctx, patternKey, pattern)
+			}
+
+			// wrap writer
+			ww, rw := wrapResponse(w)
+
 			// call next handler
-			next.ServeHTTP(w, r.WithContext(ctx))
+			r = r.WithContext(ctx)
+			next.ServeHTTP(ww, r)
+
+			// rename span using pattern
+			if config.Patterns {
+				if *pattern == "" {
+					*pattern = requestPattern(r)
+				}
+				if *pattern != "" {
+					span.Rename(patternName(r.Method, *pattern))
+				}
+			}
+
+			// get status
+			status := rw.status
+			if status == 0 && rw.hijacked {
+				status = http.StatusSwitchingProtocols
+			} else if status == 0 {
+				status = http.StatusOK
+			}
+
+			// tag response
+			span.Set(HTTPStatusCode(status))
+			span.Tag("http.response_size", rw.size)
+
+			// tag response headers
+			for _, key := range config.ResponseHeaders {
+				if values := w.Header().Values(key); len(values) > 0 {
+					span.Tag("http.response.header."+strings.ToLower(key), redactValue(key, strings.Join(values, ", "), config.SensitiveKeys))
+				}
+			}
+
+			// mark server errors
+			if status >= 500 {
+				span.Native().SetStatus(codes.Error, http.StatusText(status))
+			}
 		})
 	}
 }
 
+type responseWriter struct {
+	http.ResponseWriter
+	status   int
+	size     int64
+	hijacked bool
+}
+
+// wrapResponse will wrap the provided writer to track the response. The
+// returned writer only implements the optional http.Flusher, http.Hijacker and
+// http.Pusher interfaces if the provided writer implements them.
+func wrapResponse(w http.ResponseWriter) (http.ResponseWriter, *responseWriter) {
+	// create writer
+	rw := &responseWriter{ResponseWriter: w}
+
+	// check interfaces
+	_, isFlusher := w.(http.Flusher)
+	_, isHijacker := w.(http.Hijacker)
+	_, isPusher := w.(http.Pusher)
+
+	// prepare wrappers
+	f := flushWriter{rw}
+	h := hijackWriter{rw}
+	p := pushWriter{rw}
+
+	switch {
+	case isFlusher && isHijacker && isPusher:
+		return struct {
+			*responseWriter
+			flushWriter
+			hijackWriter
+			pushWriter
+		}{rw, f, h, p}, rw
+	case isFlusher && isHijacker:
+		return struct {
+			*responseWriter
+			flushWriter
+			hijackWriter
+		}{rw, f, h}, rw
+	case isFlusher && isPusher:
+		return struct {
+			*responseWriter
+			flushWriter
+			pushWriter
+		}{rw, f, p}, rw
+	case isHijacker && isPusher:
+		return struct {
+			*responseWriter
+			hijackWriter
+			pushWriter
+		}{rw, h, p}, rw
+	case isFlusher:
+		return struct {
+			*responseWriter
+			flushWriter
+		}{rw, f}, rw
+	case isHijacker:
+		return struct {
+			*responseWriter
+			hijackWriter
+		}{rw, h}, rw
+	case isPusher:
+		return struct {
+			*responseWriter
+			pushWriter
+		}{rw, p}, rw
+	default:
+		return rw, rw
+	}
+}
+
+// trackResponse will wrap the provided writer unless it is already tracked.
+func trackResponse(w http.ResponseWriter) http.ResponseWriter {
+	// reuse tracking writer
+	if trackedResponse(w) != nil {
+		return w
+	}
+
+	w, _ = wrapResponse(w)
+
+	return w
+}
+
+// trackedResponse will return the tracking writer of a wrapped writer.
+func trackedResponse(w http.ResponseWriter) *responseWriter {
+	if tracked, ok := w.(interface{ tracker() *responseWriter }); ok {
+		return tracked.tracker()
+	}
+
+	return nil
+}
+
+func (w *responseWriter) tracker() *responseWriter {
+	return w
+}
+
+func (w *responseWriter) started() bool {
+	return w.status != 0 || w.hijacked
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	// set final status
+	if w.status == 0 && status >= 200 {
+		w.status = status
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(p []byte) (int, error) {
+	// set implicit status
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	// write data
+	n, err := w.ResponseWriter.Write(p)
+	w.size += int64(n)
+
+	return n, err
+}
+
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+type flushWriter struct {
+	rw *responseWriter
+}
+
+func (w flushWriter) Flush() {
+	// set implicit status
+	if w.rw.status == 0 {
+		w.rw.status = http.StatusOK
+	}
+
+	// flush data
+	w.rw.ResponseWriter.(http.Flusher).Flush()
+}
+
+type hijackWriter struct {
+	rw *responseWriter
+}
+
+func (w hijackWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	// hijack connection
+	conn, buf, err := w.rw.ResponseWriter.(http.Hijacker).Hijack()
+	if err == nil {
+		w.rw.hijacked = true
+	}
+
+	return conn, buf, err
+}
+
+type pushWriter struct {
+	rw *responseWriter
+}
+
+func (w pushWriter) Push(target string, opts *http.PushOptions) error {
+	return w.rw.ResponseWriter.(http.Pusher).Push(target, opts)
+}
+
+func cleanPath(path string, cleaners []func([]string) []string) string {
+	// split path
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	// run cleaners
+	for _, cleaner := range cleaners {
+		segments = cleaner(segments)
+	}
+
+	return strings.Join(segments, "/")
+}
+
+func isSensitive(key string, sensitiveKeys []string) bool {
+	// check keys
+	key = strings.ToLower(key)
+	for _, sensitiveKey := range sensitiveKeys {
+		if strings.Contains(key, strings.ToLower(sensitiveKey)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func redactValue(key, value string, sensitiveKeys []string) string {
+	// check key
+	if isSensitive(key, sensitiveKeys) {
+		return "REDACTED"
+	}
+
+	return value
+}
+
+func redactURL(u *url.URL, sensitiveKeys []string) string {
+	// check query
+	if u.RawQuery == "" {
+		return u.Redacted()
+	}
+
+	// redact query values
+	pairs := strings.Split(u.RawQuery, "&")
+	for i, pair := range pairs {
+		key, _, _ := strings.Cut(pair, "=")
+		if unescaped, err := url.QueryUnescape(key); err == nil {
+			key = unescaped
+		}
+		if isSensitive(key, sensitiveKeys) {
+			pairs[i] = strings.SplitN(pair, "=", 2)[0] + "=REDACTED"
+		}
+	}
+
+	// copy url
+	uu := *u
+	uu.RawQuery = strings.Join(pairs, "&")
+
+	return uu.Redacted()
+}
+
+// PatternHandler is the middleware used to report the pattern of the matched
+// http.ServeMux route to the root handler. It should wrap the mux directly if
+// other middleware replaces the request between the root handler and the mux.
+func PatternHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// call next handler
+		next.ServeHTTP(w, r)
+
+		// store pattern
+		if holder, ok := r.Context().Value(patternKey).(*string); ok {
+			if pattern := requestPattern(r); pattern != "" {
+				*holder = pattern
+			}
+		}
+	})
+}
+
+func patternName(method, pattern string) string {
+	// check method
+	if strings.Contains(pattern, " ") {
+		return pattern
+	}
+
+	return method + " " + pattern
+}
+
 // NumberCleaner will return a function that replaces number-like URL segments
 // with a "#". If fullNumber is true it will only replace if the whole segment is
 // a number instead of just the first character.
@@ -61,3 +452,49 @@ func NumberCleaner(fullNumber bool) func([]string) []string {
 		return segments
 	}
 }
+
+// UUIDCleaner will return a function that replaces UUID URL segments with a
+// "#".
+func UUIDCleaner() func([]string) []string {
+	return replaceSegments(isUUID)
+}
+
+// HexCleaner will return a function that replaces hexadecimal URL segments of
+// at least the specified length with a "#". This matches hashes and similar
+// identifiers.
+func HexCleaner(minLength int) func([]string) []string {
+	return replaceSegments(func(s string) bool {
+		return len(s) >= minLength && isHex(s)
+	})
+}
+
+// ObjectIDCleaner will return a function that replaces BSON ObjectID URL
+// segments with a "#".
+func ObjectIDCleaner() func([]string) []string {
+	return replaceSegments(func(s string) bool {
+		return len(s) == 24 && isHex(s)
+	})
+}
+
+// TokenCleaner will return a function that replaces base64 (standard or URL)
+// encoded URL segments of at least the specified length with a "#". To prevent
+// matching words, a segment must mix at least two of upper case letters, lower
+// case letters and digits.
+func TokenCleaner(minLength int) func([]string) []string {
+	return replaceSegments(func(s string) bool {
+		return len(s) >= minLength && isToken(s)
+	})
+}
+
+func replaceSegments(fn func(string) bool) func([]string) []string {
+	return func(segments []string) []string {
+		// replace matching segments
+		for i, s := range segments {
+			if s != "" && fn(s) {
+				segments[i] = "#"
+			}
+		}
+
+		return segments
+	}
+}
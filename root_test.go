@@ -2,10 +2,12 @@ package xo
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/256dpi/serve"
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/baggage"
 )
 
 func TestRootHandler(t *testing.T) {
@@ -30,12 +32,204 @@ func TestRootHandler(t *testing.T) {
 			},
 			{
 				Name: "GET /foo/#/bar/#",
+				Kind: "server",
 				Attributes: M{
-					"http.proto": "HTTP/1.1",
-					"http.host":  "example.com",
-					"http.url":   "/foo/123/bar/1bc5",
+					"http.proto":         "HTTP/1.1",
+					"http.host":          "example.com",
+					"http.url":           "/foo/123/bar/1bc5",
+					"http.status_code":   int64(200),
+					"http.response_size": int64(0),
 				},
 			},
 		}, tester.ReducedSpans(0))
 	})
 }
+
+func TestRootHandlerPropagation(t *testing.T) {
+	Test(func(tester *Tester) {
+		var member string
+		handler := serve.Compose(
+			RootHandlerWithConfig(RootConfig{
+				Baggage: true,
+			}),
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				member = baggage.FromContext(r.Context()).Member("foo").Value()
+				w.WriteHeader(http.StatusOK)
+			}),
+		)
+
+		res := serve.Record(handler, "GET", "/foo", map[string]string{
+			"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			"baggage":     "foo=bar",
+		}, "")
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "bar", member)
+
+		assert.Len(t, tester.Spans, 1)
+		assert.Equal(t, "GET /foo", tester.Spans[0].Name)
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", tester.Spans[0].Trace)
+		assert.Equal(t, "", tester.Spans[0].Parent)
+	})
+
+	Test(func(tester *Tester) {
+		handler := serve.Compose(
+			RootHandler(),
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}),
+		)
+
+		res := serve.Record(handler, "GET", "/foo", nil, "")
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		assert.Len(t, tester.Spans, 1)
+		assert.NotEqual(t, "4bf92f3577b34da6a3ce929d0e0e4736", tester.Spans[0].Trace)
+	})
+}
+
+func TestRootHandlerResponse(t *testing.T) {
+	Test(func(tester *Tester) {
+		handler := serve.Compose(
+			RootHandler(),
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/stream":
+					_, _ = w.Write([]byte("Hello "))
+					w.(http.Flusher).Flush()
+					_, _ = w.Write([]byte("world!"))
+				case "/fail":
+					w.WriteHeader(http.StatusBadGateway)
+					_, _ = w.Write([]byte("fail"))
+				case "/hijack":
+					_, ok := w.(http.Hijacker)
+					assert.False(t, ok)
+					_, ok = w.(http.Pusher)
+					assert.False(t, ok)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}),
+		)
+
+		res := serve.Record(handler, "GET", "/stream", nil, "")
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.True(t, res.Flushed)
+		assert.Equal(t, "Hello world!", res.Body.String())
+
+		res = serve.Record(handler, "GET", "/fail", nil, "")
+		assert.Equal(t, http.StatusBadGateway, res.Code)
+
+		res = serve.Record(handler, "GET", "/hijack", nil, "")
+		assert.Equal(t, http.StatusNotFound, res.Code)
+
+		spans := tester.ReducedSpans(0)
+		assert.Len(t, spans, 3)
+		assert.Equal(t, int64(200), spans[0].Attributes["http.status_code"])
+		assert.Equal(t, int64(12), spans[0].Attributes["http.response_size"])
+		assert.Equal(t, "", spans[0].Status)
+		assert.Equal(t, int64(502), spans[1].Attributes["http.status_code"])
+		assert.Equal(t, int64(4), spans[1].Attributes["http.response_size"])
+		assert.Equal(t, "error", spans[1].Status)
+		assert.Equal(t, int64(404), spans[2].Attributes["http.status_code"])
+		assert.Equal(t, "", spans[2].Status)
+	})
+}
+
+func TestRootHandlerInterfaces(t *testing.T) {
+	Test(func(tester *Tester) {
+		done := make(chan struct{})
+		handler := RootHandler()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, ok := w.(http.Flusher)
+			assert.True(t, ok)
+			_, ok = w.(http.Pusher)
+			assert.False(t, ok)
+
+			conn, _, err := w.(http.Hijacker).Hijack()
+			assert.NoError(t, err)
+			_, _ = conn.Write([]byte("HTTP/1.1 204 No Content\r\n\r\n"))
+			_ = conn.Close()
+		}))
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			handler.ServeHTTP(w, r)
+			close(done)
+		}))
+		defer server.Close()
+
+		res, err := http.Get(server.URL)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, res.StatusCode)
+		assert.NoError(t, res.Body.Close())
+		<-done
+
+		spans := tester.ReducedSpans(0)
+		assert.Len(t, spans, 1)
+		assert.Equal(t, int64(101), spans[0].Attributes["http.status_code"])
+	})
+}
+
+func TestRootHandlerCapture(t *testing.T) {
+	Test(func(tester *Tester) {
+		handler := serve.Compose(
+			RootHandlerWithConfig(RootConfig{
+				RequestHeaders:  []string{"X-Foo", "Authorization", "X-Missing"},
+				ResponseHeaders: []string{"X-Bar", "Set-Cookie"},
+				QueryParams:     []string{"page", "access_token"},
+			}),
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Bar", "baz")
+				w.Header().Set("Set-Cookie", "session=secret")
+				w.WriteHeader(http.StatusOK)
+			}),
+		)
+
+		res := serve.Record(handler, "GET", "/foo?page=2&access_token=abc&password=secret&q=a%20b", map[string]string{
+			"X-Foo":         "bar",
+			"Authorization": "Bearer abc",
+		}, "")
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		spans := tester.ReducedSpans(0)
+		assert.Len(t, spans, 1)
+		assert.Equal(t, "/foo?page=2&access_token=REDACTED&password=REDACTED&q=a%20b", spans[0].Attributes["http.url"])
+		assert.Equal(t, "bar", spans[0].Attributes["http.request.header.x-foo"])
+		assert.Equal(t, "REDACTED", spans[0].Attributes["http.request.header.authorization"])
+		assert.NotContains(t, spans[0].Attributes, "http.request.header.x-missing")
+		assert.Equal(t, "baz", spans[0].Attributes["http.response.header.x-bar"])
+		assert.Equal(t, "REDACTED", spans[0].Attributes["http.response.header.set-cookie"])
+		assert.Equal(t, "2", spans[0].Attributes["http.query.page"])
+		assert.Equal(t, "REDACTED", spans[0].Attributes["http.query.access_token"])
+	})
+}
+
+func TestRootHandlerNaming(t *testing.T) {
+	Test(func(tester *Tester) {
+		handler := serve.Compose(
+			RootHandlerWithConfig(RootConfig{
+				Cleaners: []func([]string) []string{
+					UUIDCleaner(),
+					ObjectIDCleaner(),
+					HexCleaner(32),
+					TokenCleaner(16),
+				},
+			}),
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+		)
+
+		serve.Record(handler, "GET", "/a/123e4567-e89b-12d3-a456-426614174000/b/5f1a0e7c9d3b2a1e4c8f6d7a/c/d41d8cd98f00b204e9800998ecf8427e/d/dGhpcy1pcy1hLXRva2Vu/settings", nil, "")
+
+		handler = serve.Compose(
+			RootHandlerWithConfig(RootConfig{
+				Namer: func(r *http.Request) string {
+					return "custom " + r.Method
+				},
+			}),
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+		)
+
+		serve.Record(handler, "POST", "/foo", nil, "")
+
+		spans := tester.ReducedSpans(0)
+		assert.Equal(t, "GET /a/#/b/#/c/#/d/#/settings", spans[0].Name)
+		assert.Equal(t, "custom POST", spans[1].Name)
+	})
+}
@@ -2,6 +2,9 @@ package xo
 
 import (
 	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Context is used by Run to track execution.
@@ -20,11 +23,21 @@ func (c *Context) Rename(name string) {
 	c.Span.Rename(name)
 }
 
+// Set will add the provided typed attributes to the span.
+func (c *Context) Set(attributes ...attribute.KeyValue) {
+	c.Span.Set(attributes...)
+}
+
 // Attach will add the provided event to the span.
 func (c *Context) Attach(event string, attributes M) {
 	c.Span.Attach(event, attributes)
 }
 
+// Link will add a link to the span found in the provided context.
+func (c *Context) Link(ctx context.Context, attributes M) {
+	c.Span.Link(ctx, attributes)
+}
+
 // Log will attach a log event to the span.
 func (c *Context) Log(format string, args ...interface{}) {
 	c.Span.Log(format, args...)
@@ -38,16 +51,23 @@ func (c *Context) Tag(key string, value interface{}) {
 // Run will run the provided function and automatically handle tracing, error
 // handling and panic recovering.
 func Run(ctx context.Context, fn func(ctx *Context) error) error {
+	return run(ctx, GetCaller(1, 0), nil, fn)
+}
+
+// RunWith will run the provided function like Run and start the span with the
+// provided options e.g. the span kind or links.
+func RunWith(ctx context.Context, opts []trace.SpanStartOption, fn func(ctx *Context) error) error {
+	return run(ctx, GetCaller(1, 0), opts, fn)
+}
+
+func run(ctx context.Context, caller Caller, opts []trace.SpanStartOption, fn func(ctx *Context) error) error {
 	// ensure context
 	if ctx == nil {
 		ctx = context.Background()
 	}
 
-	// get caller
-	caller := GetCaller(1, 0)
-
 	// trace
-	ctx, span := Trace(ctx, caller.Short)
+	ctx, span := Trace(ctx, caller.Short, opts...)
 	defer span.End()
 
 	// wrap
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func TestRun(t *testing.T) {
@@ -52,6 +53,7 @@ func TestRun(t *testing.T) {
 			},
 			{
 				Name:       "xo.TestRun.func1",
+				Status:     "error",
 				Attributes: M{"tag": int64(42)},
 				Events: []VEvent{
 					{
@@ -70,7 +72,8 @@ func TestRun(t *testing.T) {
 				},
 			},
 			{
-				Name: "xo.TestRun.func1",
+				Name:   "xo.TestRun.func1",
+				Status: "error",
 				Attributes: M{
 					"tag": int64(42),
 				},
@@ -94,6 +97,44 @@ func TestRun(t *testing.T) {
 	})
 }
 
+func TestRunWith(t *testing.T) {
+	Test(func(tester *Tester) {
+		remote, err := WithRemoteParent(nil, "4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7", true)
+		assert.NoError(t, err)
+
+		err = RunWith(nil, []trace.SpanStartOption{
+			trace.WithSpanKind(trace.SpanKindConsumer),
+		}, func(ctx *Context) error {
+			ctx.Tag("tag", 42)
+			ctx.Link(remote, nil)
+			return F("error")
+		})
+		assert.Error(t, err)
+		assert.Equal(t, "xo.TestRunWith.func1: error", fmt.Sprintf("%v", err))
+
+		assert.Equal(t, []VSpan{
+			{
+				Name:       "xo.TestRunWith.func1",
+				Kind:       "consumer",
+				Status:     "error",
+				Attributes: M{"tag": int64(42)},
+				Links: []VLink{
+					{Trace: "4bf92f3577b34da6a3ce929d0e0e4736", Span: "00f067aa0ba902b7"},
+				},
+				Events: []VEvent{
+					{
+						Name: "exception",
+						Attributes: M{
+							"exception.message": "error",
+							"exception.type":    "*xo.Err",
+						},
+					},
+				},
+			},
+		}, tester.ReducedSpans(0))
+	})
+}
+
 func BenchmarkRun(b *testing.B) {
 	ctx := context.Background()
 
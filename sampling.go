@@ -0,0 +1,204 @@
+package xo
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SamplingRule defines the sampler used for spans with a matching name.
+type SamplingRule struct {
+	// The span name to match. A trailing "*" matches any span name with the
+	// preceding prefix.
+	Name string
+
+	// The sampler used for matching spans.
+	Sampler sdkTrace.Sampler
+}
+
+func (r SamplingRule) match(name string) bool {
+	// check prefix
+	if strings.HasSuffix(r.Name, "*") {
+		return strings.HasPrefix(name, strings.TrimSuffix(r.Name, "*"))
+	}
+
+	return name == r.Name
+}
+
+// SamplingConfig is used to configure a sampler.
+type SamplingConfig struct {
+	// The rules evaluated in order before applying the ratio and rate.
+	Rules []SamplingRule
+
+	// The ratio of traces to sample.
+	//
+	// Default: 1.
+	Ratio float64
+
+	// The maximum number of traces sampled per second. Zero disables the limit.
+	Rate float64
+
+	// Whether to apply the rules, ratio and rate to every span instead of only
+	// to root spans. By default, child spans follow the sampling decision of
+	// their parent to keep traces complete.
+	IgnoreParent bool
+}
+
+// Ensure will ensure defaults.
+func (c *SamplingConfig) Ensure() {
+	// set default ratio
+	if c.Ratio == 0 {
+		c.Ratio = 1
+	}
+}
+
+// Sampler will return a sampler that implements the configured policy for root
+// spans and follows the parent span otherwise.
+func (c SamplingConfig) Sampler() sdkTrace.Sampler {
+	// ensure config
+	c.Ensure()
+
+	// prepare samplers
+	var samplers []sdkTrace.Sampler
+	if c.Ratio < 1 {
+		samplers = append(samplers, sdkTrace.TraceIDRatioBased(c.Ratio))
+	}
+	if c.Rate > 0 {
+		samplers = append(samplers, RateSampler(c.Rate))
+	}
+
+	// prepare sampler
+	var sampler sdkTrace.Sampler
+	switch len(samplers) {
+	case 0:
+		sampler = sdkTrace.AlwaysSample()
+	case 1:
+		sampler = samplers[0]
+	default:
+		sampler = &allSampler{samplers: samplers}
+	}
+
+	// apply rules
+	if len(c.Rules) > 0 {
+		sampler = RuleSampler(c.Rules, sampler)
+	}
+
+	// apply parent
+	if !c.IgnoreParent {
+		sampler = sdkTrace.ParentBased(sampler)
+	}
+
+	return sampler
+}
+
+// RuleSampler will return a sampler that delegates to the sampler of the first
+// rule that matches the span name or the fallback sampler if none matches.
+func RuleSampler(rules []SamplingRule, fallback sdkTrace.Sampler) sdkTrace.Sampler {
+	return &ruleSampler{
+		rules:    rules,
+		fallback: fallback,
+	}
+}
+
+type ruleSampler struct {
+	rules    []SamplingRule
+	fallback sdkTrace.Sampler
+}
+
+func (s *ruleSampler) ShouldSample(params sdkTrace.SamplingParameters) sdkTrace.SamplingResult {
+	// check rules
+	for _, rule := range s.rules {
+		if rule.match(params.Name) {
+			return rule.Sampler.ShouldSample(params)
+		}
+	}
+
+	return s.fallback.ShouldSample(params)
+}
+
+func (s *ruleSampler) Description() string {
+	return fmt.Sprintf("RuleSampler{rules:%d,fallback:%s}", len(s.rules), s.fallback.Description())
+}
+
+// RateSampler will return a sampler that samples at most the specified number
+// of traces per second.
+func RateSampler(perSecond float64) sdkTrace.Sampler {
+	// determine burst
+	burst := math.Max(1, perSecond)
+
+	return &rateSampler{
+		rate:   perSecond,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+type rateSampler struct {
+	rate   float64
+	burst  float64
+	mutex  sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func (s *rateSampler) ShouldSample(params sdkTrace.SamplingParameters) sdkTrace.SamplingResult {
+	// acquire mutex
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// refill tokens
+	now := time.Now()
+	s.tokens = math.Min(s.burst, s.tokens+now.Sub(s.last).Seconds()*s.rate)
+	s.last = now
+
+	// prepare result
+	result := sdkTrace.SamplingResult{
+		Decision:   sdkTrace.Drop,
+		Tracestate: trace.SpanContextFromContext(params.ParentContext).TraceState(),
+	}
+
+	// take token
+	if s.tokens >= 1 {
+		s.tokens--
+		result.Decision = sdkTrace.RecordAndSample
+	}
+
+	return result
+}
+
+func (s *rateSampler) Description() string {
+	return fmt.Sprintf("RateSampler{%g}", s.rate)
+}
+
+type allSampler struct {
+	samplers []sdkTrace.Sampler
+}
+
+func (s *allSampler) ShouldSample(params sdkTrace.SamplingParameters) sdkTrace.SamplingResult {
+	// check samplers
+	var result sdkTrace.SamplingResult
+	for _, sampler := range s.samplers {
+		result = sampler.ShouldSample(params)
+		if result.Decision != sdkTrace.RecordAndSample {
+			return result
+		}
+	}
+
+	return result
+}
+
+func (s *allSampler) Description() string {
+	// collect descriptions
+	list := make([]string, 0, len(s.samplers))
+	for _, sampler := range s.samplers {
+		list = append(list, sampler.Description())
+	}
+
+	return fmt.Sprintf("AllSampler{%s}", strings.Join(list, ","))
+}
@@ -0,0 +1,98 @@
+package xo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func sample(sampler sdkTrace.Sampler, name string) bool {
+	return sampler.ShouldSample(sdkTrace.SamplingParameters{
+		ParentContext: context.Background(),
+		Name:          name,
+	}).Decision == sdkTrace.RecordAndSample
+}
+
+func TestRuleSampler(t *testing.T) {
+	sampler := RuleSampler([]SamplingRule{
+		{Name: "POST /payments", Sampler: sdkTrace.AlwaysSample()},
+		{Name: "GET /health*", Sampler: sdkTrace.NeverSample()},
+	}, sdkTrace.TraceIDRatioBased(0))
+
+	assert.True(t, sample(sampler, "POST /payments"))
+	assert.False(t, sample(sampler, "POST /payments/#"))
+	assert.False(t, sample(sampler, "GET /health"))
+	assert.False(t, sample(sampler, "GET /healthz"))
+	assert.False(t, sample(sampler, "GET /foo"))
+	assert.Equal(t, "RuleSampler{rules:2,fallback:TraceIDRatioBased{0}}", sampler.Description())
+}
+
+func TestRateSampler(t *testing.T) {
+	sampler := RateSampler(2)
+	assert.True(t, sample(sampler, "foo"))
+	assert.True(t, sample(sampler, "foo"))
+	assert.False(t, sample(sampler, "foo"))
+	assert.Equal(t, "RateSampler{2}", sampler.Description())
+}
+
+func TestSamplingConfig(t *testing.T) {
+	sampler := SamplingConfig{IgnoreParent: true}.Sampler()
+	assert.Equal(t, "AlwaysOnSampler", sampler.Description())
+
+	sampler = SamplingConfig{Ratio: 0.5, Rate: 1, IgnoreParent: true}.Sampler()
+	assert.Equal(t, "AllSampler{TraceIDRatioBased{0.5},RateSampler{1}}", sampler.Description())
+
+	sampler = SamplingConfig{}.Sampler()
+	assert.Contains(t, sampler.Description(), "ParentBased{root:AlwaysOnSampler")
+
+	sampler = SamplingConfig{
+		Rules: []SamplingRule{
+			{Name: "GET /health", Sampler: sdkTrace.NeverSample()},
+		},
+		Rate: 1,
+	}.Sampler()
+	assert.False(t, sample(sampler, "GET /health"))
+	assert.True(t, sample(sampler, "GET /foo"))
+	assert.False(t, sample(sampler, "GET /foo"))
+}
+
+func TestHookTracingSampler(t *testing.T) {
+	Test(func(tester *Tester) {
+		revert := HookTracingWithConfig(TracingConfig{
+			Exporter:    tester.SpanExporter(),
+			ServiceName: "xo",
+			Sampler: SamplingConfig{
+				Rules: []SamplingRule{
+					{Name: "GET /health", Sampler: sdkTrace.NeverSample()},
+					{Name: "POST /payments", Sampler: sdkTrace.AlwaysSample()},
+				},
+				Ratio: 0.0001,
+				Rate:  1,
+			}.Sampler(),
+		})
+		defer revert()
+
+		ctx, span := Trace(context.Background(), "GET /health")
+		_, child := Trace(ctx, "check")
+		child.End()
+		span.End()
+
+		ctx, span = Trace(context.Background(), "POST /payments")
+		_, child = Trace(ctx, "GET /health")
+		child.End()
+		_, child = Trace(ctx, "insert")
+		child.End()
+		_, child = Trace(ctx, "notify")
+		child.End()
+		span.End()
+
+		assert.Equal(t, []VSpan{
+			{Name: "GET /health"},
+			{Name: "insert"},
+			{Name: "notify"},
+			{Name: "POST /payments"},
+		}, tester.ReducedSpans(0))
+	})
+}
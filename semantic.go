@@ -0,0 +1,92 @@
+package xo
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/semconv/v1.5.0"
+)
+
+// DBSystem returns the "db.system" attribute e.g. "postgresql" or "mongodb".
+func DBSystem(system string) attribute.KeyValue {
+	return semconv.DBSystemKey.String(system)
+}
+
+// DBName returns the "db.name" attribute.
+func DBName(name string) attribute.KeyValue {
+	return semconv.DBNameKey.String(name)
+}
+
+// DBStatement returns the "db.statement" attribute.
+func DBStatement(statement string) attribute.KeyValue {
+	return semconv.DBStatementKey.String(statement)
+}
+
+// DBOperation returns the "db.operation" attribute e.g. "SELECT" or "findOne".
+func DBOperation(operation string) attribute.KeyValue {
+	return semconv.DBOperationKey.String(operation)
+}
+
+// MessagingSystem returns the "messaging.system" attribute e.g. "kafka".
+func MessagingSystem(system string) attribute.KeyValue {
+	return semconv.MessagingSystemKey.String(system)
+}
+
+// MessagingDestination returns the "messaging.destination" attribute.
+func MessagingDestination(destination string) attribute.KeyValue {
+	return semconv.MessagingDestinationKey.String(destination)
+}
+
+// MessagingOperation returns the "messaging.operation" attribute e.g.
+// "receive" or "process".
+func MessagingOperation(operation string) attribute.KeyValue {
+	return semconv.MessagingOperationKey.String(operation)
+}
+
+// MessagingMessageID returns the "messaging.message_id" attribute.
+func MessagingMessageID(id string) attribute.KeyValue {
+	return semconv.MessagingMessageIDKey.String(id)
+}
+
+// HTTPMethod returns the "http.method" attribute.
+func HTTPMethod(method string) attribute.KeyValue {
+	return semconv.HTTPMethodKey.String(method)
+}
+
+// HTTPURL returns the "http.url" attribute.
+func HTTPURL(url string) attribute.KeyValue {
+	return semconv.HTTPURLKey.String(url)
+}
+
+// HTTPHost returns the "http.host" attribute.
+func HTTPHost(host string) attribute.KeyValue {
+	return semconv.HTTPHostKey.String(host)
+}
+
+// HTTPRoute returns the "http.route" attribute.
+func HTTPRoute(route string) attribute.KeyValue {
+	return semconv.HTTPRouteKey.String(route)
+}
+
+// HTTPStatusCode returns the "http.status_code" attribute.
+func HTTPStatusCode(code int) attribute.KeyValue {
+	return semconv.HTTPStatusCodeKey.Int(code)
+}
+
+// HTTPUserAgent returns the "http.user_agent" attribute.
+func HTTPUserAgent(userAgent string) attribute.KeyValue {
+	return semconv.HTTPUserAgentKey.String(userAgent)
+}
+
+// RPCSystem returns the "rpc.system" attribute e.g. "grpc".
+func RPCSystem(system string) attribute.KeyValue {
+	return semconv.RPCSystemKey.String(system)
+}
+
+// RPCMethod returns the "rpc.method" attribute.
+func RPCMethod(method string) attribute.KeyValue {
+	return semconv.RPCMethodKey.String(method)
+}
+
+// RPCGRPCStatusCode returns the "rpc.grpc.status_code" attribute.
+func RPCGRPCStatusCode(code int) attribute.KeyValue {
+	return semconv.RPCGRPCStatusCodeKey.Int(code)
+}
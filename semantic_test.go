@@ -0,0 +1,98 @@
+package xo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestSemanticAttributes(t *testing.T) {
+	Test(func(tester *Tester) {
+		_, span := Trace(context.Background(), "query", trace.WithSpanKind(trace.SpanKindClient))
+		span.Set(
+			DBSystem("postgresql"),
+			DBName("app"),
+			DBStatement("SELECT 1"),
+			DBOperation("SELECT"),
+		)
+		span.End()
+
+		_, span = Trace(context.Background(), "publish", trace.WithSpanKind(trace.SpanKindProducer))
+		span.Set(
+			MessagingSystem("kafka"),
+			MessagingDestination("events"),
+			MessagingMessageID("1"),
+		)
+		span.End()
+
+		_, span = Trace(context.Background(), "consume", trace.WithSpanKind(trace.SpanKindConsumer))
+		span.Set(MessagingOperation("process"))
+		span.End()
+
+		_, span = Trace(context.Background(), "request", trace.WithSpanKind(trace.SpanKindServer))
+		span.Set(
+			HTTPMethod("GET"),
+			HTTPURL("/foo"),
+			HTTPHost("example.com"),
+			HTTPRoute("/{id}"),
+			HTTPStatusCode(200),
+			HTTPUserAgent("test"),
+		)
+		span.End()
+
+		_, span = Trace(context.Background(), "call")
+		span.Set(RPCSystem("grpc"), RPCMethod("Foo"), RPCGRPCStatusCode(0))
+		span.End()
+
+		assert.Equal(t, []VSpan{
+			{
+				Name: "query",
+				Kind: "client",
+				Attributes: M{
+					"db.system":    "postgresql",
+					"db.name":      "app",
+					"db.statement": "SELECT 1",
+					"db.operation": "SELECT",
+				},
+			},
+			{
+				Name: "publish",
+				Kind: "producer",
+				Attributes: M{
+					"messaging.system":      "kafka",
+					"messaging.destination": "events",
+					"messaging.message_id":  "1",
+				},
+			},
+			{
+				Name: "consume",
+				Kind: "consumer",
+				Attributes: M{
+					"messaging.operation": "process",
+				},
+			},
+			{
+				Name: "request",
+				Kind: "server",
+				Attributes: M{
+					"http.method":      "GET",
+					"http.url":         "/foo",
+					"http.host":        "example.com",
+					"http.route":       "/{id}",
+					"http.status_code": int64(200),
+					"http.user_agent":  "test",
+				},
+			},
+			{
+				Name: "call",
+				Attributes: M{
+					"rpc.system":           "grpc",
+					"rpc.method":           "Foo",
+					"rpc.grpc.status_code": int64(0),
+				},
+			},
+		}, tester.ReducedSpans(0))
+	})
+}
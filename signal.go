@@ -0,0 +1,44 @@
+package xo
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+var exit = os.Exit
+
+// HandleSignals will call the provided teardown function and exit the process
+// once SIGTERM or SIGINT is received. The exit code follows the shell convention
+// of 128 plus the signal number. A second signal received during the teardown
+// will terminate the process immediately. The returned function may be called
+// to stop handling signals.
+func HandleSignals(teardown func()) func() {
+	// register signals
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+
+	// prepare done
+	done := make(chan struct{})
+
+	// handle signals
+	go func() {
+		select {
+		case sig := <-signals:
+			// restore default behaviour
+			signal.Stop(signals)
+
+			// run teardown
+			teardown()
+
+			// exit process
+			exit(128 + int(sig.(syscall.Signal)))
+		case <-done:
+		}
+	}()
+
+	return func() {
+		signal.Stop(signals)
+		close(done)
+	}
+}
@@ -0,0 +1,43 @@
+package xo
+
+import (
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleSignals(t *testing.T) {
+	codes := make(chan int, 1)
+	exit = func(code int) {
+		codes <- code
+	}
+	defer func() {
+		exit = os.Exit
+	}()
+
+	var called bool
+	HandleSignals(func() {
+		called = true
+	})
+
+	err := syscall.Kill(os.Getpid(), syscall.SIGTERM)
+	assert.NoError(t, err)
+
+	select {
+	case code := <-codes:
+		assert.True(t, called)
+		assert.Equal(t, 143, code)
+	case <-time.After(time.Second):
+		t.Fatal("timeout")
+	}
+}
+
+func TestHandleSignalsStop(t *testing.T) {
+	stop := HandleSignals(func() {
+		t.Fatal("unexpected teardown")
+	})
+	stop()
+}
@@ -0,0 +1,181 @@
+package xo
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TailSamplingConfig is used to configure a tail sampler.
+type TailSamplingConfig struct {
+	// The duration after which a span causes its trace to be kept. Zero
+	// disables the latency check.
+	Latency time.Duration
+
+	// The predicate that causes a trace to be kept if it returns true for any
+	// of its spans.
+	Predicate func(sdkTrace.ReadOnlySpan) bool
+
+	// The ratio of the remaining traces to keep. Zero drops all traces that
+	// are not kept by the other checks.
+	Ratio float64
+
+	// The maximum number of traces buffered at once. If exceeded, the decision
+	// for a buffered trace is made early.
+	//
+	// Default: 10000.
+	MaxTraces int
+}
+
+// Ensure will ensure defaults.
+func (c *TailSamplingConfig) Ensure() {
+	// set default max traces
+	if c.MaxTraces == 0 {
+		c.MaxTraces = 10000
+	}
+}
+
+// TailSampler is a span processor that buffers spans per trace and forwards
+// the whole trace to the next processor when the local root span ends if any
+// span errored, exceeded the latency or matched the predicate. Otherwise, the
+// trace is kept at the configured ratio. Spans must be sampled by the head
+// sampler to be seen by the tail sampler.
+type TailSampler struct {
+	next    sdkTrace.SpanProcessor
+	config  TailSamplingConfig
+	sampler sdkTrace.Sampler
+	traces  map[trace.TraceID][]sdkTrace.ReadOnlySpan
+	mutex   sync.Mutex
+}
+
+// NewTailSampler will create and return a new tail sampler that forwards kept
+// traces to the provided processor.
+func NewTailSampler(next sdkTrace.SpanProcessor, config TailSamplingConfig) *TailSampler {
+	// ensure config
+	config.Ensure()
+
+	return &TailSampler{
+		next:    next,
+		config:  config,
+		sampler: sdkTrace.TraceIDRatioBased(config.Ratio),
+		traces:  map[trace.TraceID][]sdkTrace.ReadOnlySpan{},
+	}
+}
+
+// OnStart implements the sdkTrace.SpanProcessor interface.
+func (s *TailSampler) OnStart(ctx context.Context, span sdkTrace.ReadWriteSpan) {
+	s.next.OnStart(ctx, span)
+}
+
+// OnEnd implements the sdkTrace.SpanProcessor interface.
+func (s *TailSampler) OnEnd(span sdkTrace.ReadOnlySpan) {
+	// acquire mutex
+	s.mutex.Lock()
+
+	// evict a trace if full
+	var evicted []sdkTrace.ReadOnlySpan
+	traceID := span.SpanContext().TraceID()
+	if _, ok := s.traces[traceID]; !ok && len(s.traces) >= s.config.MaxTraces {
+		for id, spans := range s.traces {
+			evicted = spans
+			delete(s.traces, id)
+			break
+		}
+	}
+
+	// buffer span
+	s.traces[traceID] = append(s.traces[traceID], span)
+
+	// get trace if root
+	var spans []sdkTrace.ReadOnlySpan
+	if isRootSpan(span) {
+		spans = s.traces[traceID]
+		delete(s.traces, traceID)
+	}
+
+	// release mutex
+	s.mutex.Unlock()
+
+	// process traces
+	if evicted != nil {
+		s.process(evicted)
+	}
+	if spans != nil {
+		s.process(spans)
+	}
+}
+
+// Shutdown implements the sdkTrace.SpanProcessor interface. Buffered traces
+// are processed before the next processor is shut down.
+func (s *TailSampler) Shutdown(ctx context.Context) error {
+	// process buffered traces
+	s.flush()
+
+	return s.next.Shutdown(ctx)
+}
+
+// ForceFlush implements the sdkTrace.SpanProcessor interface. Buffered traces
+// are processed early before the next processor is flushed.
+func (s *TailSampler) ForceFlush(ctx context.Context) error {
+	// process buffered traces
+	s.flush()
+
+	return s.next.ForceFlush(ctx)
+}
+
+func (s *TailSampler) flush() {
+	// take buffered traces
+	s.mutex.Lock()
+	traces := s.traces
+	s.traces = map[trace.TraceID][]sdkTrace.ReadOnlySpan{}
+	s.mutex.Unlock()
+
+	// process traces
+	for _, spans := range traces {
+		s.process(spans)
+	}
+}
+
+func (s *TailSampler) process(spans []sdkTrace.ReadOnlySpan) {
+	// check decision
+	if !s.keep(spans) {
+		return
+	}
+
+	// forward spans
+	for _, span := range spans {
+		s.next.OnEnd(span)
+	}
+}
+
+func (s *TailSampler) keep(spans []sdkTrace.ReadOnlySpan) bool {
+	// check spans
+	for _, span := range spans {
+		// check status
+		if span.Status().Code == codes.Error {
+			return true
+		}
+
+		// check latency
+		if s.config.Latency > 0 && span.EndTime().Sub(span.StartTime()) >= s.config.Latency {
+			return true
+		}
+
+		// check predicate
+		if s.config.Predicate != nil && s.config.Predicate(span) {
+			return true
+		}
+	}
+
+	// check ratio
+	result := s.sampler.ShouldSample(sdkTrace.SamplingParameters{
+		ParentContext: context.Background(),
+		TraceID:       spans[0].SpanContext().TraceID(),
+	})
+
+	return result.Decision == sdkTrace.RecordAndSample
+}
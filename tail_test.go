@@ -0,0 +1,146 @@
+package xo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/codes"
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestTailSampler(t *testing.T) {
+	Test(func(tester *Tester) {
+		revert := HookTracingWithConfig(TracingConfig{
+			Exporter:    tester.SpanExporter(),
+			ServiceName: "xo",
+			TailSampling: &TailSamplingConfig{
+				Latency: 20 * time.Millisecond,
+				Predicate: func(span sdkTrace.ReadOnlySpan) bool {
+					return span.Name() == "keep"
+				},
+			},
+		})
+		defer revert()
+
+		// dropped
+		ctx, span := Trace(context.Background(), "drop")
+		_, child := Trace(ctx, "child")
+		child.End()
+		span.End()
+
+		// errored
+		ctx, span = Trace(context.Background(), "error")
+		_, child = Trace(ctx, "child")
+		child.Record(F("fail"))
+		child.End()
+		span.End()
+
+		// slow
+		ctx, span = Trace(context.Background(), "slow")
+		_, child = Trace(ctx, "child")
+		time.Sleep(25 * time.Millisecond)
+		child.End()
+		span.End()
+
+		// predicate
+		ctx, span = Trace(context.Background(), "root")
+		_, child = Trace(ctx, "keep")
+		child.End()
+		span.End()
+
+		spans := tester.ReducedSpans(0)
+		names := make([]string, 0, len(spans))
+		for _, span := range spans {
+			names = append(names, span.Name)
+		}
+		assert.Equal(t, []string{"child", "error", "child", "slow", "keep", "root"}, names)
+	})
+}
+
+func TestTailSamplerRatio(t *testing.T) {
+	Test(func(tester *Tester) {
+		revert := HookTracingWithConfig(TracingConfig{
+			Exporter:    tester.SpanExporter(),
+			ServiceName: "xo",
+			TailSampling: &TailSamplingConfig{
+				Ratio: 1,
+			},
+		})
+		defer revert()
+
+		ctx, span := Trace(context.Background(), "root")
+		_, child := Trace(ctx, "child")
+		child.End()
+		span.End()
+
+		assert.Len(t, tester.Spans, 2)
+	})
+}
+
+func TestTailSamplerEviction(t *testing.T) {
+	Test(func(tester *Tester) {
+		revert := HookTracingWithConfig(TracingConfig{
+			Exporter:    tester.SpanExporter(),
+			ServiceName: "xo",
+			TailSampling: &TailSamplingConfig{
+				MaxTraces: 1,
+			},
+		})
+		defer revert()
+
+		ctx1, span1 := Trace(context.Background(), "one")
+		_, child1 := Trace(ctx1, "child")
+		child1.Record(F("fail"))
+		child1.End()
+
+		ctx2, span2 := Trace(context.Background(), "two")
+		_, child2 := Trace(ctx2, "child")
+		child2.End()
+		assert.Len(t, tester.Spans, 1)
+
+		span2.End()
+		span1.End()
+		assert.Len(t, tester.Spans, 1)
+	})
+}
+
+func TestTailSamplerFlush(t *testing.T) {
+	Test(func(tester *Tester) {
+		sampler := NewTailSampler(sdkTrace.NewSimpleSpanProcessor(tester.SpanExporter()), TailSamplingConfig{})
+		provider := sdkTrace.NewTracerProvider(sdkTrace.WithSpanProcessor(sampler))
+		tracer := provider.Tracer("xo")
+
+		ctx, span := tracer.Start(context.Background(), "root")
+		_, child := tracer.Start(ctx, "child")
+		child.SetStatus(codes.Error, "fail")
+		child.End()
+		assert.Len(t, tester.Spans, 0)
+
+		err := sampler.ForceFlush(context.Background())
+		assert.NoError(t, err)
+		assert.Len(t, tester.Spans, 1)
+
+		span.End()
+		assert.Len(t, tester.Spans, 1)
+	})
+}
+
+func TestTailSamplerShutdown(t *testing.T) {
+	Test(func(tester *Tester) {
+		sampler := NewTailSampler(sdkTrace.NewSimpleSpanProcessor(tester.SpanExporter()), TailSamplingConfig{})
+		provider := sdkTrace.NewTracerProvider(sdkTrace.WithSpanProcessor(sampler))
+		tracer := provider.Tracer("xo")
+
+		ctx, _ := tracer.Start(context.Background(), "root")
+		_, child := tracer.Start(ctx, "child")
+		child.SetStatus(codes.Error, "fail")
+		child.End()
+		assert.Len(t, tester.Spans, 0)
+
+		err := provider.Shutdown(context.Background())
+		assert.NoError(t, err)
+		assert.Len(t, tester.Spans, 1)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -21,12 +22,59 @@ func SmartTrace(ctx context.Context) (context.Context, Span) {
 
 // Trace is used to trace a function call. It will start a new span based on the
 // history in the provided context. It will return a new span and context that
-// contains the created spans native span.
-func Trace(ctx context.Context, name string) (context.Context, Span) {
-	ctx, span := StartSpan(ctx, name)
+// contains the created spans native span. Additional options e.g. links or the
+// span kind may be provided to configure the span.
+func Trace(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, Span) {
+	ctx, span := StartSpan(ctx, name, opts...)
 	return ctx, NewSpan(ctx, span)
 }
 
+// NewLink will return a link to the span found in the provided context.
+func NewLink(ctx context.Context, attributes M) trace.Link {
+	return trace.Link{
+		SpanContext: trace.SpanContextFromContext(ctx),
+		Attributes:  mapToKV(attributes),
+	}
+}
+
+// WithRemoteParent will return a context that carries a remote span context
+// built from the provided hex encoded trace and span ID. Spans started from the
+// returned context will continue the remote trace.
+func WithRemoteParent(ctx context.Context, traceID, spanID string, sampled bool) (context.Context, error) {
+	// ensure context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// parse trace id
+	tid, err := trace.TraceIDFromHex(traceID)
+	if err != nil {
+		return nil, W(err)
+	}
+
+	// parse span id
+	sid, err := trace.SpanIDFromHex(spanID)
+	if err != nil {
+		return nil, W(err)
+	}
+
+	// prepare flags
+	var flags trace.TraceFlags
+	if sampled {
+		flags = trace.FlagsSampled
+	}
+
+	// create span context
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    tid,
+		SpanID:     sid,
+		TraceFlags: flags,
+		Remote:     true,
+	})
+
+	return trace.ContextWithRemoteSpanContext(ctx, spanContext), nil
+}
+
 // NewSpan will create and return a new span from the provided context and
 // native span. The context should already carry the native span.
 func NewSpan(ctx context.Context, span trace.Span) Span {
@@ -65,19 +113,46 @@ func (s Span) Tag(key string, value interface{}) {
 	s.span.SetAttributes(kv)
 }
 
+// Set will add the provided typed attributes e.g. DBStatement to the span.
+func (s Span) Set(attributes ...attribute.KeyValue) {
+	s.span.SetAttributes(attributes...)
+}
+
 // Attach will add the provided event to the span.
 func (s Span) Attach(event string, attributes M) {
 	s.span.AddEvent(event, trace.WithAttributes(mapToKV(attributes)...))
 }
 
+// Link will add a link to the span found in the provided context.
+func (s Span) Link(ctx context.Context, attributes M) {
+	s.span.AddLink(NewLink(ctx, attributes))
+}
+
 // Log will attach a log event to the span.
 func (s Span) Log(format string, args ...interface{}) {
 	s.span.AddEvent("log", trace.WithAttributes(attribute.String("message", fmt.Sprintf(format, args...))))
 }
 
-// Record will attach an error event to the span.
+// Record will attach an error event to the span and set the span status to
+// error. Error fields are added as attributes to the event. Nil errors are
+// ignored.
 func (s Span) Record(err error) {
-	s.span.RecordError(err)
+	// check error
+	if err == nil {
+		return
+	}
+
+	s.recordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+func (s Span) recordError(err error) {
+	s.span.RecordError(err, trace.WithAttributes(mapToKV(GetFields(err))...))
+}
+
+// OK will explicitly set the span status to ok.
+func (s Span) OK() {
+	s.span.SetStatus(codes.Ok, "")
 }
 
 // End will end the span.
@@ -1,10 +1,12 @@
 package xo
 
 import (
+	"bytes"
 	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func TestSmartTrace(t *testing.T) {
@@ -69,7 +71,8 @@ func TestTraceMeta(t *testing.T) {
 
 		assert.Equal(t, []VSpan{
 			{
-				Name: "foo",
+				Name:   "foo",
+				Status: "error",
 				Attributes: M{
 					"foo":  "bar",
 					"rich": `{"foo":"bar"}`,
@@ -100,6 +103,68 @@ func TestTraceMeta(t *testing.T) {
 	})
 }
 
+func TestTraceRecordFields(t *testing.T) {
+	Test(func(tester *Tester) {
+		_, span := Trace(nil, "foo")
+		span.Record(WithFields(F("some error"), M{"foo": "bar"}))
+		span.End()
+
+		assert.Equal(t, []VSpan{
+			{
+				Name:   "foo",
+				Status: "error",
+				Events: []VEvent{
+					{
+						Name: "exception",
+						Attributes: M{
+							"exception.message": "some error",
+							"exception.type":    "*xo.Err",
+							"foo":               "bar",
+						},
+					},
+				},
+			},
+		}, tester.ReducedSpans(0))
+	})
+}
+
+func TestTraceRecordNil(t *testing.T) {
+	Test(func(tester *Tester) {
+		_, span := Trace(nil, "foo")
+		span.Record(nil)
+		span.End()
+
+		tracer, _ := CreateTracer(nil, "bar")
+		tracer.Record(nil)
+		tracer.End()
+
+		assert.Equal(t, []VSpan{
+			{
+				Name: "foo",
+			},
+			{
+				Name: "bar",
+			},
+		}, tester.ReducedSpans(0))
+	})
+}
+
+func TestTraceStatus(t *testing.T) {
+	Test(func(tester *Tester) {
+		_, span := Trace(nil, "foo")
+		span.OK()
+		span.End()
+
+		_, span = Trace(nil, "bar")
+		span.Record(F("some error"))
+		span.End()
+
+		spans := tester.ReducedSpans(0)
+		assert.Equal(t, "ok", spans[0].Status)
+		assert.Equal(t, "error", spans[1].Status)
+	})
+}
+
 func BenchmarkTraceRoot(b *testing.B) {
 	b.ReportAllocs()
 
@@ -147,3 +212,80 @@ func BenchmarkTraceChildParallel(b *testing.B) {
 		}
 	})
 }
+
+func TestTraceLinks(t *testing.T) {
+	Test(func(tester *Tester) {
+		ctx1, span1 := Trace(nil, "one")
+		span1.End()
+
+		ctx2, span2 := Trace(nil, "two")
+		span2.End()
+
+		ctx, span := Trace(nil, "three", trace.WithLinks(
+			NewLink(ctx1, M{"foo": "bar"}),
+		))
+		span.Link(ctx2, M{"baz": 42})
+		span.End()
+
+		traceID1 := span1.Native().SpanContext().TraceID().String()
+		spanID1 := span1.Native().SpanContext().SpanID().String()
+		traceID2 := span2.Native().SpanContext().TraceID().String()
+		spanID2 := span2.Native().SpanContext().SpanID().String()
+		assert.NotEqual(t, traceID1, GetSpan(ctx).SpanContext().TraceID().String())
+
+		assert.Equal(t, []VSpan{
+			{Name: "one"},
+			{Name: "two"},
+			{
+				Name: "three",
+				Links: []VLink{
+					{Trace: traceID1, Span: spanID1, Attributes: M{"foo": "bar"}},
+					{Trace: traceID2, Span: spanID2, Attributes: M{"baz": int64(42)}},
+				},
+			},
+		}, tester.ReducedSpans(0))
+	})
+}
+
+func TestTraceLinksDebugger(t *testing.T) {
+	var buf bytes.Buffer
+	debugger := NewDebugger(DebugConfig{
+		TraceOutput: &buf,
+	})
+
+	revert := HookTracing(debugger.SpanExporter(), "xo", false)
+	defer revert()
+
+	ctx, err := WithRemoteParent(nil, "4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7", true)
+	assert.NoError(t, err)
+
+	_, span := Trace(nil, "foo", trace.WithLinks(NewLink(ctx, nil)))
+	span.End()
+
+	assert.Contains(t, buf.String(), "> foo")
+	assert.Contains(t, buf.String(), "~link")
+	assert.Contains(t, buf.String(), "4bf92f3577b34da6a3ce929d0e0e4736/00f067aa0ba902b7")
+}
+
+func TestWithRemoteParent(t *testing.T) {
+	Test(func(tester *Tester) {
+		ctx, err := WithRemoteParent(nil, "4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7", true)
+		assert.NoError(t, err)
+
+		_, span := Trace(ctx, "foo")
+		span.End()
+
+		assert.Len(t, tester.Spans, 1)
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", tester.Spans[0].Trace)
+		assert.Equal(t, "", tester.Spans[0].Parent)
+		assert.Equal(t, "00f067aa0ba902b7", span.Native().(interface {
+			Parent() trace.SpanContext
+		}).Parent().SpanID().String())
+
+		_, err = WithRemoteParent(nil, "foo", "00f067aa0ba902b7", true)
+		assert.Error(t, err)
+
+		_, err = WithRemoteParent(nil, "4bf92f3577b34da6a3ce929d0e0e4736", "foo", true)
+		assert.Error(t, err)
+	})
+}
@@ -2,6 +2,10 @@ package xo
 
 import (
 	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type tracerContextKey struct{}
@@ -30,9 +34,15 @@ func (c *tracerContext) Value(key interface{}) (val interface{}) {
 //
 // Code that uses Trace or native opentelemetry APIs will automatically discover
 // the stack and branch of its tail if no previous branch has been detected.
+//
+// The stack is safe for concurrent use. However, spans pushed from multiple
+// goroutines share a single stack and may end up with the wrong parent. Use
+// Fork to obtain a separate tracer for each goroutine.
 type Tracer struct {
-	root  Span
-	stack []Span
+	root   Span
+	stack  []Span
+	parent *Tracer
+	mutex  sync.Mutex
 }
 
 // NewTracer returns a new tracer that will use the native span found in the
@@ -85,9 +95,15 @@ func (t *Tracer) SmartPush() {
 	t.Push(GetCaller(1, 1).Short)
 }
 
-// Push will add a new span onto the stack.
-func (t *Tracer) Push(name string) {
-	_, child := Trace(t.Tail().ctx, name)
+// Push will add a new span onto the stack. Additional options e.g. links or the
+// span kind may be provided to configure the span.
+func (t *Tracer) Push(name string, opts ...trace.SpanStartOption) {
+	// acquire mutex
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	// push span
+	_, child := Trace(t.tail().ctx, name, opts...)
 	t.stack = append(t.stack, child)
 }
 
@@ -101,49 +117,138 @@ func (t *Tracer) Tag(key string, value interface{}) {
 	t.Tail().Tag(key, value)
 }
 
+// Set will add the provided typed attributes to the tail span.
+func (t *Tracer) Set(attributes ...attribute.KeyValue) {
+	t.Tail().Set(attributes...)
+}
+
 // Attach will add the provided event to the tail span.
 func (t *Tracer) Attach(event string, attributes M) {
 	t.Tail().Attach(event, attributes)
 }
 
+// Link will add a link to the span found in the provided context to the tail
+// span.
+func (t *Tracer) Link(ctx context.Context, attributes M) {
+	t.Tail().Link(ctx, attributes)
+}
+
 // Log will attach a log event to the tail span.
 func (t *Tracer) Log(format string, args ...interface{}) {
 	t.Tail().Log(format, args...)
 }
 
-// Record will attach an error event to the tail span.
+// Record will attach an error event to the tail span and set its status to
+// error.
 func (t *Tracer) Record(err error) {
 	t.Tail().Record(err)
 }
 
+// OK will explicitly set the tail span status to ok.
+func (t *Tracer) OK() {
+	t.Tail().OK()
+}
+
 // Pop ends and removes the last pushed span. This call is usually deferred
 // right after a push.
 func (t *Tracer) Pop() {
+	// acquire mutex
+	t.mutex.Lock()
+
 	// check list
 	if len(t.stack) == 0 {
+		t.mutex.Unlock()
 		return
 	}
 
-	// end last span
-	t.stack[len(t.stack)-1].End()
+	// get last span
+	span := t.stack[len(t.stack)-1]
 
 	// resize stack
 	t.stack = t.stack[:len(t.stack)-1]
+
+	// release mutex
+	t.mutex.Unlock()
+
+	// end span
+	span.End()
 }
 
-// End will end all stacked spans and the root span.
+// End will end all stacked spans and the root span. The root span of a forked
+// tracer is not ended.
 func (t *Tracer) End() {
+	// acquire mutex
+	t.mutex.Lock()
+
+	// take stack
+	stack := t.stack
+	t.stack = nil
+
+	// release mutex
+	t.mutex.Unlock()
+
 	// end stacked spans
-	for _, span := range t.stack {
+	for _, span := range stack {
 		span.End()
 	}
 
 	// end root span
-	t.root.End()
+	if t.parent == nil {
+		t.root.End()
+	}
+}
+
+// Fork will return a new tracer that uses the current tail as its root. The
+// returned context is the provided context wrapped with the new tracer. The
+// forked tracer should be used by a single goroutine and joined before the
+// current tail is popped.
+func (t *Tracer) Fork(ctx context.Context) (*Tracer, context.Context) {
+	// get tail
+	tail := t.Tail()
+
+	// check context
+	if ctx == nil {
+		ctx = tail.ctx
+	}
+
+	// create tracer
+	tracer := &Tracer{
+		root:   tail,
+		stack:  make([]Span, 0, 32),
+		parent: t,
+	}
+
+	// add tracer
+	ctx = &tracerContext{
+		Context: ctx,
+		tracer:  tracer,
+	}
+
+	return tracer, ctx
+}
+
+// Join will end all spans stacked on the provided tracer forked from this
+// tracer.
+func (t *Tracer) Join(tracer *Tracer) {
+	// check parent
+	if tracer.parent != t {
+		Panic(F("tracer not forked from this tracer"))
+	}
+
+	// end spans
+	tracer.End()
 }
 
 // Tail returns the tail or root of the span stack.
 func (t *Tracer) Tail() Span {
+	// acquire mutex
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return t.tail()
+}
+
+func (t *Tracer) tail() Span {
 	// return last span if available
 	if len(t.stack) > 0 {
 		return t.stack[len(t.stack)-1]
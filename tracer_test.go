@@ -1,9 +1,13 @@
 package xo
 
 import (
+	"context"
+	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func TestTracer(t *testing.T) {
@@ -36,7 +40,8 @@ func TestTracer(t *testing.T) {
 		tracer.End()
 		assert.Equal(t, []VSpan{
 			{
-				Name: "xo.TestTracer.func1",
+				Name:   "xo.TestTracer.func1",
+				Status: "error",
 				Events: []VEvent{
 					{
 						Name: "exception",
@@ -76,3 +81,127 @@ func TestNakedTracer(t *testing.T) {
 	assert.Equal(t, tracer.Root().Native(), GetSpan(ctx))
 	assert.Equal(t, tracer.Tail().Native(), GetSpan(ctx))
 }
+
+func TestTracerLinks(t *testing.T) {
+	Test(func(tester *Tester) {
+		ctx, err := WithRemoteParent(nil, "4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7", true)
+		assert.NoError(t, err)
+
+		tracer, _ := CreateTracer(nil, "root")
+		tracer.Push("foo", trace.WithLinks(NewLink(ctx, nil)))
+		tracer.Pop()
+		tracer.Link(ctx, M{"foo": "bar"})
+		tracer.End()
+
+		assert.Equal(t, []VSpan{
+			{
+				Name: "foo",
+				Links: []VLink{
+					{Trace: "4bf92f3577b34da6a3ce929d0e0e4736", Span: "00f067aa0ba902b7"},
+				},
+			},
+			{
+				Name: "root",
+				Links: []VLink{
+					{Trace: "4bf92f3577b34da6a3ce929d0e0e4736", Span: "00f067aa0ba902b7", Attributes: M{"foo": "bar"}},
+				},
+			},
+		}, tester.ReducedSpans(0))
+	})
+}
+
+func TestTracerFork(t *testing.T) {
+	Test(func(tester *Tester) {
+		tracer, ctx := CreateTracer(nil, "root")
+		tracer.Push("parent")
+
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			child, childCtx := tracer.Fork(ctx)
+			go func(i int) {
+				defer wg.Done()
+				defer tracer.Join(child)
+
+				assert.Equal(t, child, GetTracer(childCtx))
+				assert.Equal(t, tracer.Tail().Native(), GetSpan(childCtx))
+
+				child.Push(fmt.Sprintf("worker-%d", i))
+				assert.Equal(t, child.Tail().Native(), GetSpan(childCtx))
+
+				_, span := Trace(childCtx, "task")
+				span.End()
+
+				child.Push("open")
+			}(i)
+		}
+		wg.Wait()
+
+		assert.Equal(t, tracer, GetTracer(ctx))
+		assert.Equal(t, tracer.Tail().Native(), GetSpan(ctx))
+
+		tracer.Pop()
+		tracer.End()
+
+		spans := tester.Spans
+		assert.Len(t, spans, 14)
+
+		// index spans
+		byID := map[string]VSpan{}
+		for _, span := range spans {
+			byID[span.ID] = span
+		}
+
+		// check parents
+		for _, span := range spans {
+			switch span.Name {
+			case "root":
+				assert.Equal(t, "", span.Parent)
+			case "parent":
+				assert.Equal(t, "root", byID[span.Parent].Name)
+			case "task", "open":
+				assert.Contains(t, byID[span.Parent].Name, "worker-")
+			default:
+				assert.Equal(t, "parent", byID[span.Parent].Name)
+			}
+		}
+	})
+}
+
+func TestTracerJoinPanic(t *testing.T) {
+	tracer1, _ := NewTracer(nil)
+	tracer2, _ := NewTracer(nil)
+	child, _ := tracer1.Fork(nil)
+
+	assert.Panics(t, func() {
+		tracer2.Join(child)
+	})
+
+	assert.NotPanics(t, func() {
+		tracer1.Join(child)
+	})
+}
+
+func TestTracerConcurrency(t *testing.T) {
+	Test(func(tester *Tester) {
+		tracer, ctx := CreateTracer(context.Background(), "root")
+
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					tracer.Push("foo")
+					tracer.Tag("j", j)
+					_ = GetSpan(ctx)
+					tracer.Pop()
+				}
+			}()
+		}
+		wg.Wait()
+
+		tracer.End()
+		assert.Len(t, tester.Spans, 801)
+	})
+}
@@ -3,15 +3,20 @@ package xo
 import (
 	"context"
 	"sync"
+	"time"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	exportTrace "go.opentelemetry.io/otel/sdk/trace"
 	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
-	"go.opentelemetry.io/otel/semconv/v1.5.0"
 	"go.opentelemetry.io/otel/trace"
 )
 
+// Propagator is the propagator used to inject and extract trace context when
+// crossing process boundaries.
+var Propagator propagation.TextMapPropagator = propagation.TraceContext{}
+
 // atomic.Value does not work as it requires the same concrete type
 var tracerCache sync.Map
 
@@ -50,24 +55,98 @@ func ResetGlobalTracer() {
 	tracerCache.Store("xo", otel.Tracer("xo"))
 }
 
+// TracingConfig is used to configure tracing.
+type TracingConfig struct {
+	// The exporter used to export spans.
+	Exporter exportTrace.SpanExporter
+
+	// The service name.
+	ServiceName string
+
+	// The service version.
+	ServiceVersion string
+
+	// The deployment environment.
+	Environment string
+
+	// The service instance ID.
+	InstanceID string
+
+	// Additional resource attributes.
+	Attributes M
+
+	// Whether to skip the detection of host, process and container attributes
+	// using DetectResource.
+	NoDetection bool
+
+	// Whether spans should be exported asynchronously in batches.
+	Async bool
+
+	// The sampler used to sample traces.
+	//
+	// Default: sdkTrace.AlwaysSample().
+	Sampler sdkTrace.Sampler
+
+	// The optional tail sampling applied to finished traces.
+	TailSampling *TailSamplingConfig
+
+	// The maximum duration to wait for the provider to flush and shut down
+	// when reverting.
+	//
+	// Default: 5s.
+	ShutdownTimeout time.Duration
+}
+
+// Ensure will ensure defaults.
+func (c *TracingConfig) Ensure() {
+	// set default sampler
+	if c.Sampler == nil {
+		c.Sampler = sdkTrace.AlwaysSample()
+	}
+
+	// set default shutdown timeout
+	if c.ShutdownTimeout == 0 {
+		c.ShutdownTimeout = 5 * time.Second
+	}
+}
+
 // HookTracing will hook tracing using the provided span exporter. The returned
-// function may be called to revert the previously configured provider.
+// function may be called to revert the previously configured provider and shut
+// down the created provider.
 func HookTracing(exporter exportTrace.SpanExporter, serviceName string, async bool) func() {
+	return HookTracingWithConfig(TracingConfig{
+		Exporter:    exporter,
+		ServiceName: serviceName,
+		Async:       async,
+	})
+}
+
+// HookTracingWithConfig will hook tracing using the provided config. The
+// returned function may be called to revert the previously configured provider.
+// Reverting will flush and shut down the created provider and capture any
+// errors.
+func HookTracingWithConfig(config TracingConfig) func() {
+	// ensure config
+	config.Ensure()
+
 	// prepare span processor
-	var spanProcessor sdkTrace.TracerProviderOption
-	if async {
-		spanProcessor = sdkTrace.WithBatcher(exporter)
+	var spanProcessor sdkTrace.SpanProcessor
+	if config.Async {
+		spanProcessor = sdkTrace.NewBatchSpanProcessor(config.Exporter)
 	} else {
-		spanProcessor = sdkTrace.WithSyncer(exporter)
+		spanProcessor = sdkTrace.NewSimpleSpanProcessor(config.Exporter)
+	}
+
+	// wrap span processor
+	if config.TailSampling != nil {
+		spanProcessor = NewTailSampler(spanProcessor, *config.TailSampling)
 	}
 
 	// create provider
 	provider := sdkTrace.NewTracerProvider(
-		spanProcessor,
-		sdkTrace.WithSampler(sdkTrace.AlwaysSample()),
-		sdkTrace.WithResource(resource.NewSchemaless(
-			semconv.ServiceNameKey.String(serviceName),
-		)),
+		sdkTrace.WithSpanProcessor(spanProcessor),
+		sdkTrace.WithSampler(config.Sampler),
+		sdkTrace.WithResource(resource.NewSchemaless(buildResource(config)...)),
 	)
 
 	// swap provider
@@ -83,20 +162,28 @@ func HookTracing(exporter exportTrace.SpanExporter, serviceName string, async bo
 
 		// reset cache
 		ResetGlobalTracer()
+
+		// flush and shut down provider
+		ctx, cancel := context.WithTimeout(context.Background(), config.ShutdownTimeout)
+		defer cancel()
+		err := Join(provider.ForceFlush(ctx), provider.Shutdown(ctx))
+		if err != nil {
+			Capture(WF(err, "failed to shut down tracer provider"))
+		}
 	}
 }
 
 // StartSpan will start a native span using the globally configured tracer. It
 // will continue any span found in the provided context or start a new span with
 // the specified name if absent.
-func StartSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+func StartSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
 	// ensure context
 	if ctx == nil {
 		ctx = context.Background()
 	}
 
 	// start span
-	ctx, span := GetGlobalTracer().Start(ctx, name)
+	ctx, span := GetGlobalTracer().Start(ctx, name, opts...)
 
 	return ctx, span
 }
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -48,3 +49,43 @@ func TestGetSpan(t *testing.T) {
 		}, tester.ReducedSpans(0))
 	})
 }
+
+func TestHookTracingShutdown(t *testing.T) {
+	var spans int
+	revert := HookTracingWithConfig(TracingConfig{
+		Exporter: SpanExporter(func(span sdkTrace.ReadOnlySpan) error {
+			spans++
+			return nil
+		}),
+		ServiceName: "xo",
+		Async:       true,
+	})
+
+	_, span := Trace(context.Background(), "foo")
+	span.End()
+	assert.Equal(t, 0, spans)
+
+	revert()
+	assert.Equal(t, 1, spans)
+}
+
+func TestHookTracingShutdownError(t *testing.T) {
+	Test(func(tester *Tester) {
+		revert := HookTracingWithConfig(TracingConfig{
+			Exporter: SpanExporter(func(span sdkTrace.ReadOnlySpan) error {
+				return F("export failed")
+			}),
+			ServiceName: "xo",
+			Async:       true,
+		})
+
+		_, span := Trace(context.Background(), "foo")
+		span.End()
+
+		revert()
+
+		reports := tester.ReducedReports(false)
+		assert.Len(t, reports, 1)
+		assert.Equal(t, "failed to shut down tracer provider: export failed", reports[0].Exceptions[len(reports[0].Exceptions)-1].Value)
+	})
+}
@@ -0,0 +1,128 @@
+package xo
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Transport is an HTTP transport that traces outgoing requests. The span of a
+// request ends when the response body has been fully read or closed.
+type Transport struct {
+	// The underlying transport.
+	//
+	// Default: http.DefaultTransport.
+	Base http.RoundTripper
+
+	// The cleaners applied to the URL segments to construct the span name.
+	Cleaners []func([]string) []string
+
+	// The propagator used to inject the trace context into outgoing headers.
+	//
+	// Default: Propagator.
+	Propagator propagation.TextMapPropagator
+
+	// The keys of query parameters whose values are redacted in the URL. A key
+	// matches if it contains an entry, ignoring case. User info is always
+	// redacted.
+	//
+	// Default: SensitiveKeys.
+	SensitiveKeys []string
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *Transport) RoundTrip(r *http.Request) (*http.Response, error) {
+	// get base
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	// get propagator
+	propagator := t.Propagator
+	if propagator == nil {
+		propagator = Propagator
+	}
+
+	// get sensitive keys
+	sensitiveKeys := t.SensitiveKeys
+	if sensitiveKeys == nil {
+		sensitiveKeys = SensitiveKeys
+	}
+
+	// construct name
+	name := fmt.Sprintf("%s %s/%s", r.Method, r.URL.Host, cleanPath(r.URL.Path, t.Cleaners))
+
+	// create span
+	ctx, span := Trace(r.Context(), name, trace.WithSpanKind(trace.SpanKindClient))
+	span.Set(HTTPMethod(r.Method), HTTPURL(redactURL(r.URL, sensitiveKeys)))
+
+	// clone request and inject context
+	r = r.Clone(ctx)
+	propagator.Inject(ctx, propagation.HeaderCarrier(r.Header))
+
+	// perform request
+	res, err := base.RoundTrip(r)
+	if err != nil {
+		span.Record(err)
+		span.End()
+		return nil, err
+	}
+
+	// tag status
+	span.Set(HTTPStatusCode(res.StatusCode))
+
+	// wrap body
+	res.Body = &tracedBody{
+		ReadCloser: res.Body,
+		span:       span,
+	}
+
+	return res, nil
+}
+
+type tracedBody struct {
+	io.ReadCloser
+	span Span
+	size int64
+	once sync.Once
+}
+
+func (b *tracedBody) Read(p []byte) (int, error) {
+	// read data
+	n, err := b.ReadCloser.Read(p)
+	b.size += int64(n)
+
+	// finish span if done
+	if err == io.EOF {
+		b.finish(nil)
+	} else if err != nil {
+		b.finish(err)
+	}
+
+	return n, err
+}
+
+func (b *tracedBody) Close() error {
+	// close body
+	err := b.ReadCloser.Close()
+
+	// finish span
+	b.finish(nil)
+
+	return err
+}
+
+func (b *tracedBody) finish(err error) {
+	b.once.Do(func() {
+		b.span.Tag("http.response_size", b.size)
+		if err != nil {
+			b.span.Record(err)
+		}
+		b.span.End()
+	})
+}
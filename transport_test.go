@@ -0,0 +1,101 @@
+package xo
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransport(t *testing.T) {
+	Test(func(tester *Tester) {
+		var header http.Header
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header = r.Header
+			_, _ = w.Write([]byte("Hello world!"))
+		}))
+		defer server.Close()
+
+		client := &http.Client{
+			Transport: &Transport{
+				Cleaners: []func([]string) []string{NumberCleaner(true)},
+			},
+		}
+
+		ctx, span := Trace(nil, "root")
+
+		req, err := http.NewRequestWithContext(ctx, "GET", server.URL+"/foo/123", nil)
+		assert.NoError(t, err)
+
+		res, err := client.Do(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Empty(t, req.Header)
+
+		body, err := io.ReadAll(res.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, "Hello world!", string(body))
+		assert.NoError(t, res.Body.Close())
+
+		span.End()
+
+		host := strings.TrimPrefix(server.URL, "http://")
+		assert.Equal(t, []VSpan{
+			{
+				Name: "GET " + host + "/foo/#",
+				Kind: "client",
+				Attributes: M{
+					"http.method":        "GET",
+					"http.url":           server.URL + "/foo/123",
+					"http.status_code":   int64(200),
+					"http.response_size": int64(12),
+				},
+			},
+			{
+				Name: "root",
+			},
+		}, tester.ReducedSpans(0))
+		assert.Equal(t, "00-"+tester.Spans[0].Trace+"-"+tester.Spans[0].ID+"-01", header.Get("traceparent"))
+	})
+}
+
+func TestTransportRedact(t *testing.T) {
+	Test(func(tester *Tester) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("Hello world!"))
+		}))
+		defer server.Close()
+
+		client := &http.Client{
+			Transport: &Transport{},
+		}
+
+		host := strings.TrimPrefix(server.URL, "http://")
+		res, err := client.Get("http://user:pass@" + host + "/foo?token=secret&password=secret&page=1")
+		assert.NoError(t, err)
+		assert.NoError(t, res.Body.Close())
+
+		spans := tester.ReducedSpans(0)
+		assert.Len(t, spans, 1)
+		assert.Equal(t, "http://user:xxxxx@"+host+"/foo?token=REDACTED&password=REDACTED&page=1", spans[0].Attributes["http.url"])
+	})
+}
+
+func TestTransportError(t *testing.T) {
+	Test(func(tester *Tester) {
+		client := &http.Client{
+			Transport: &Transport{},
+		}
+
+		_, err := client.Get("http://0.0.0.0:1/foo")
+		assert.Error(t, err)
+
+		assert.Len(t, tester.Spans, 1)
+		assert.Equal(t, "GET 0.0.0.0:1/foo", tester.Spans[0].Name)
+		assert.Equal(t, "error", tester.Spans[0].Status)
+		assert.Equal(t, "exception", tester.Spans[0].Events[0].Name)
+	})
+}
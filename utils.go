@@ -36,6 +36,54 @@ func isNumber(s string) bool {
 	return len(s) > 0
 }
 
+func isHex(s string) bool {
+	for _, c := range s {
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F') {
+			return false
+		}
+	}
+
+	return len(s) > 0
+}
+
+func isUUID(s string) bool {
+	// check length and dashes
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return false
+	}
+
+	return isHex(s[0:8]) && isHex(s[9:13]) && isHex(s[14:18]) && isHex(s[19:23]) && isHex(s[24:])
+}
+
+func isToken(s string) bool {
+	// check characters
+	var upper, lower, digit bool
+	for i, c := range s {
+		switch {
+		case c >= 'A' && c <= 'Z':
+			upper = true
+		case c >= 'a' && c <= 'z':
+			lower = true
+		case c >= '0' && c <= '9':
+			digit = true
+		case c == '+' || c == '/' || c == '-' || c == '_':
+		case c == '=' && i >= len(s)-2:
+		default:
+			return false
+		}
+	}
+
+	// check classes
+	var classes int
+	for _, ok := range []bool{upper, lower, digit} {
+		if ok {
+			classes++
+		}
+	}
+
+	return classes >= 2
+}
+
 func repeatString(str string, count int) string {
 	if count > 0 {
 		return strings.Repeat(str, count)
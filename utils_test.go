@@ -63,3 +63,21 @@ func TestBuildDot(t *testing.T) {
 	str = buildDot(5, 5, 10)
 	assert.Equal(t, "     •    ", str)
 }
+
+func TestIsUUID(t *testing.T) {
+	assert.True(t, isUUID("123e4567-e89b-12d3-a456-426614174000"))
+	assert.True(t, isUUID("123E4567-E89B-12D3-A456-426614174000"))
+	assert.False(t, isUUID("123e4567e89b12d3a456426614174000"))
+	assert.False(t, isUUID("123e4567-e89b-12d3-a456-42661417400x"))
+	assert.False(t, isUUID("foo"))
+}
+
+func TestIsToken(t *testing.T) {
+	assert.True(t, isToken("dGVzdA=="))
+	assert.True(t, isToken("abc123"))
+	assert.True(t, isToken("a-B_c"))
+	assert.False(t, isToken("settings"))
+	assert.False(t, isToken("12345"))
+	assert.False(t, isToken("a=b1"))
+	assert.False(t, isToken("foo.bar1"))
+}
@@ -6,7 +6,9 @@ import (
 	"time"
 
 	"github.com/getsentry/sentry-go"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
 // VEvent is a virtual span event.
@@ -25,8 +27,18 @@ type VSpan struct {
 	Start      time.Time
 	End        time.Time
 	Duration   time.Duration
+	Kind       string
+	Status     string
 	Attributes M
 	Events     []VEvent
+	Links      []VLink
+}
+
+// VLink is a virtual span link.
+type VLink struct {
+	Trace      string
+	Span       string
+	Attributes M
 }
 
 // VNode is a virtual trace node.
@@ -56,12 +68,17 @@ type VException struct {
 
 // VReport is a virtual report.
 type VReport struct {
-	ID         string
-	Level      string
-	Time       time.Time
-	Context    M
-	Tags       M
-	Exceptions []VException
+	ID          string
+	Level       string
+	Time        time.Time
+	Context     M
+	Tags        M
+	Fingerprint []string
+	Exceptions  []VException
+}
+
+func isRootSpan(data trace.ReadOnlySpan) bool {
+	return !data.Parent().SpanID().IsValid() || data.Parent().IsRemote()
 }
 
 // ConvertSpan will convert a raw span to a virtual span.
@@ -76,12 +93,37 @@ func ConvertSpan(data trace.ReadOnlySpan) VSpan {
 		})
 	}
 
+	// collect links
+	var links []VLink
+	for _, link := range data.Links() {
+		links = append(links, VLink{
+			Trace:      link.SpanContext.TraceID().String(),
+			Span:       link.SpanContext.SpanID().String(),
+			Attributes: kvToMap(link.Attributes),
+		})
+	}
+
 	// get parent
 	parent := data.Parent().SpanID().String()
-	if !data.Parent().SpanID().IsValid() || data.Parent().IsRemote() {
+	if isRootSpan(data) {
 		parent = ""
 	}
 
+	// get kind
+	var kind string
+	if data.SpanKind() != oteltrace.SpanKindInternal && data.SpanKind() != oteltrace.SpanKindUnspecified {
+		kind = data.SpanKind().String()
+	}
+
+	// get status
+	var status string
+	switch data.Status().Code {
+	case codes.Error:
+		status = "error"
+	case codes.Ok:
+		status = "ok"
+	}
+
 	// add span
 	return VSpan{
 		ID:         data.SpanContext().SpanID().String(),
@@ -91,8 +133,11 @@ func ConvertSpan(data trace.ReadOnlySpan) VSpan {
 		Start:      data.StartTime(),
 		End:        data.EndTime(),
 		Duration:   data.EndTime().Sub(data.StartTime()),
+		Kind:       kind,
+		Status:     status,
 		Attributes: kvToMap(data.Attributes()),
 		Events:     events,
+		Links:      links,
 	}
 }
 
@@ -100,9 +145,10 @@ func ConvertSpan(data trace.ReadOnlySpan) VSpan {
 func ConvertReport(event *sentry.Event) VReport {
 	// prepare report
 	report := VReport{
-		ID:    string(event.EventID),
-		Level: string(event.Level),
-		Time:  event.Timestamp,
+		ID:          string(event.EventID),
+		Level:       string(event.Level),
+		Time:        event.Timestamp,
+		Fingerprint: event.Fingerprint,
 	}
 
 	// add context